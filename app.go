@@ -3,6 +3,13 @@ package main
 import (
 	"context"
 	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,12 +27,78 @@ type App struct {
 	engine   *timer.Engine
 	store    *persist.Store
 	hk       *hotkey.Manager
+	cd       *timer.Countdown
 	tmpl     *split.Template
 	attempts *split.Attempts
 	settings persist.Settings
 	version  string
+
+	// runMu serializes timer input, which arrives from bound calls, hotkeys and
+	// the countdown goroutine, so a run is started and ended by one caller at a time.
+	runMu sync.Mutex
+
+	runStartedAt    time.Time // Wall-clock start of the current run.
+	runStartSegment int       // Segment the current run started on; non-zero for partial starts.
+	lastSplitAt     time.Time // Wall-clock time of the current run's last split or skip.
+	resetArmedAt    time.Time // First press of a double-press reset; zero if not armed.
+
+	segmentUndo map[string][]segmentEdit // Per attempts entry, most recent last.
+	segmentMode *segmentMode             // Non-nil while timing a single segment instead of full runs.
+}
+
+// segmentMode times one segment of the active attempts entry on its own.
+type segmentMode struct {
+	kind     persist.SegmentLogKind // Log the runs are recorded in.
+	index    int
+	id       int // Segment ID the runs are recorded under.
+	name     string
+	ilBestMS int64 // Fastest IL run in IL mode, 0 if none.
+	goldMS   int64 // Gold from full runs, 0 if none.
+}
+
+func (m *segmentMode) data() map[string]any {
+	if m == nil {
+		return nil
+	}
+
+	return map[string]any{
+		"kind":         m.kind,
+		"segmentIndex": m.index,
+		"segmentName":  m.name,
+		"ilBestMs":     m.ilBestMS,
+		"goldMs":       m.goldMS,
+	}
+}
+
+// delta compares the running segment time with the IL best and the gold.
+// A delta is 0 when its target is unknown.
+func (m *segmentMode) delta(elapsedMS int64) map[string]any {
+	var deltaILBest, deltaGold int64
+
+	if m.ilBestMS > 0 {
+		deltaILBest = elapsedMS - m.ilBestMS
+	}
+
+	if m.goldMS > 0 {
+		deltaGold = elapsedMS - m.goldMS
+	}
+
+	return map[string]any{
+		"elapsedMs":     elapsedMS,
+		"deltaIlBestMs": deltaILBest,
+		"deltaGoldMs":   deltaGold,
+	}
+}
+
+// segmentEdit is an undo snapshot taken before a structural segment edit.
+type segmentEdit struct {
+	before    *split.Attempts
+	updatedAt time.Time // UpdatedAt right after the edit, to detect later changes.
 }
 
+// maxSegmentUndo caps how many segment edits can be undone per attempts entry.
+const maxSegmentUndo = 20
+
 // NewApp creates a new App instance.
 func NewApp(version string) *App {
 	return &App{version: version}
@@ -83,11 +156,17 @@ func (a *App) startup(ctx context.Context) {
 	runtime.WindowSetAlwaysOnTop(ctx, a.settings.AlwaysOnTop)
 
 	a.engine = timer.New(nil, a.onTick, a.onStateChange)
+	a.engine.SetSegmentChangeHandler(a.onSegmentChange)
+	a.cd = timer.NewCountdown(a.onCountdown, a.onCountdownGo)
 
 	a.hk = hotkey.NewManager(a.onHotkey)
 	if err := a.hk.Start(); err != nil {
 		fmt.Printf("Warning: could not start hotkey manager: %v\n", err)
 	}
+
+	if a.settings.AutoLockWhenIdle {
+		a.setInputLock(true)
+	}
 }
 
 func (a *App) shutdown(ctx context.Context) {
@@ -95,18 +174,69 @@ func (a *App) shutdown(ctx context.Context) {
 		a.hk.Stop()
 	}
 
+	if a.cd != nil {
+		a.cd.Cancel()
+	}
+
 	if a.engine != nil {
-		a.saveSuspendedRun()
+		a.runMu.Lock()
+		defer a.runMu.Unlock()
+
+		a.saveSuspendedRun(true)
 		a.engine.Reset()
 	}
 }
 
 func (a *App) onTick(data timer.TickData) {
 	runtime.EventsEmit(a.ctx, "timer:tick", data)
+
+	if m := a.segmentMode; m != nil && data.State == timer.Running.String() {
+		runtime.EventsEmit(a.ctx, "segmentMode:delta", m.delta(data.ElapsedMS))
+	}
 }
 
 func (a *App) onStateChange(state timer.State) {
 	runtime.EventsEmit(a.ctx, "timer:state", state.String())
+
+	if state != timer.Running && a.settings.AutoLockWhenIdle {
+		a.setInputLock(true)
+	}
+}
+
+func (a *App) onSegmentChange(index int) {
+	if a.segmentMode != nil {
+		index = a.segmentMode.index
+	}
+
+	var notes string
+
+	if a.attempts != nil {
+		if all := a.attempts.SegmentNotes(a.tmpl); index < len(all) {
+			notes = all[index]
+		}
+	}
+
+	runtime.EventsEmit(a.ctx, "notes:segment", map[string]any{
+		"segmentIndex": index,
+		"notes":        notes,
+	})
+}
+
+func (a *App) onCountdown(state timer.CountdownState) {
+	runtime.EventsEmit(a.ctx, "countdown:state", state)
+}
+
+// onCountdownGo starts the run at the countdown's go moment, unless the timer
+// was started some other way meanwhile.
+func (a *App) onCountdownGo(at time.Time) {
+	a.runMu.Lock()
+	defer a.runMu.Unlock()
+
+	if a.engine.CurrentState() != timer.Idle {
+		return
+	}
+
+	a.startRun(at)
 }
 
 func (a *App) onHotkey(action hotkey.Action) {
@@ -121,38 +251,192 @@ func (a *App) onHotkey(action hotkey.Action) {
 		a.UndoSplit()
 	case hotkey.ActionSkipSplit:
 		a.SkipSplit()
+	case hotkey.ActionHit:
+		a.Hit()
+	case hotkey.ActionUndoHit:
+		a.UndoHit()
+	case hotkey.ActionCountdown:
+		a.ToggleCountdown()
+	case hotkey.ActionToggleLock:
+		a.ToggleInputLock()
+	}
+}
+
+// LockInput blocks every timer input except unlocking, so stray key presses
+// while tabbed out or chatting cannot split or reset.
+func (a *App) LockInput() {
+	a.setInputLock(true)
+}
+
+// UnlockInput lifts the input lock.
+func (a *App) UnlockInput() {
+	a.setInputLock(false)
+}
+
+// ToggleInputLock locks input if unlocked, and unlocks it if locked.
+func (a *App) ToggleInputLock() {
+	a.setInputLock(!a.IsInputLocked())
+}
+
+// IsInputLocked reports whether timer input is locked.
+func (a *App) IsInputLocked() bool {
+	return a.hk != nil && a.hk.Locked()
+}
+
+// setInputLock changes the lock state and emits "input:lock" if it changed.
+func (a *App) setInputLock(locked bool) {
+	if a.hk == nil || !a.hk.SetLocked(locked) {
+		return
 	}
+
+	runtime.EventsEmit(a.ctx, "input:lock", locked)
 }
 
 // StartSplit is the smart start/split action.
 // If idle, starts the timer. If running, splits.
 func (a *App) StartSplit() {
+	a.runMu.Lock()
+	defer a.runMu.Unlock()
+
+	if a.IsInputLocked() {
+		return
+	}
+
 	state := a.engine.CurrentState()
 
 	switch state {
 	case timer.Idle:
-		a.engine.Start()
+		// A start during a countdown would be a false start.
+		if !a.cd.Active() {
+			a.startRun(time.Now())
+		}
 	case timer.Running:
+		a.lastSplitAt = time.Now()
 		a.engine.Split()
 		a.emitDeltas()
+		a.emitAlerts()
 		a.checkRunCompletion()
 
 		if a.engine.CurrentState() != timer.Finished {
-			a.saveSuspendedRun()
+			a.saveSuspendedRun(false)
 		}
 	case timer.Paused, timer.Finished:
 		// No-op for these states.
 	}
 }
 
+// startRun starts a full run as if it had begun at the given time.
+func (a *App) startRun(at time.Time) {
+	a.runStartedAt = at
+	a.runStartSegment = 0
+	a.lastSplitAt = time.Time{}
+	a.resetArmedAt = time.Time{}
+	a.engine.StartSince(at)
+}
+
+// StartCountdown counts down the given number of seconds, then starts the timer.
+// Returns false if the timer is not idle or a countdown is already running.
+func (a *App) StartCountdown(seconds int) bool {
+	if seconds <= 0 {
+		return false
+	}
+
+	return a.StartCountdownAt(time.Now().Add(time.Duration(seconds) * time.Second).UnixMilli())
+}
+
+// StartCountdownAt counts down to an absolute go time in Unix milliseconds.
+// Runners given the same go time by a race bot or a shared command start together.
+func (a *App) StartCountdownAt(goAtMS int64) bool {
+	if a.IsInputLocked() || a.engine.CurrentState() != timer.Idle {
+		return false
+	}
+
+	return a.cd.Start(time.UnixMilli(goAtMS))
+}
+
+// CancelCountdown stops a running countdown. Returns false if none was running.
+func (a *App) CancelCountdown() bool {
+	if a.IsInputLocked() {
+		return false
+	}
+
+	return a.cd.Cancel()
+}
+
+// ToggleCountdown cancels a running countdown, or starts one of the configured
+// CountdownSeconds. Returns whether a countdown is running afterwards.
+func (a *App) ToggleCountdown() bool {
+	if a.CancelCountdown() {
+		return false
+	}
+
+	return a.StartCountdown(a.settings.CountdownSeconds)
+}
+
+// GetCountdown returns the state of the race countdown.
+func (a *App) GetCountdown() timer.CountdownState {
+	return a.cd.State()
+}
+
+// StartFromSegment starts a run at segmentIndex with the earlier splits preloaded
+// from reference: "personal_best", "best_segments" or "zero". The attempt is a
+// partial start, so it never becomes the PB, and it only sets golds if the
+// PartialStartGolds setting allows it. Returns false if the reference lacks the
+// earlier splits or the timer is not idle.
+func (a *App) StartFromSegment(segmentIndex int, reference string) bool {
+	a.runMu.Lock()
+	defer a.runMu.Unlock()
+
+	if a.IsInputLocked() || a.attempts == nil || a.segmentMode != nil || a.engine.CurrentState() != timer.Idle {
+		return false
+	}
+
+	if segmentIndex <= 0 || segmentIndex >= len(a.attempts.Segments) {
+		return false
+	}
+
+	view := a.variableView(a.attempts)
+
+	var ref []int64
+
+	switch reference {
+	case "personal_best":
+		ref = view.PersonalBestSplits()
+	case "best_segments":
+		ref = view.BestSegmentsCumulative()
+	case "zero":
+		ref = make([]int64, segmentIndex)
+	default:
+		return false
+	}
+
+	if len(ref) < segmentIndex {
+		return false
+	}
+
+	a.runStartedAt = time.Now()
+	a.runStartSegment = segmentIndex
+	a.engine.StartAt(segmentIndex, ref[:segmentIndex])
+	a.emitDeltas()
+
+	return true
+}
+
 // TogglePause pauses if running, resumes if paused.
 func (a *App) TogglePause() {
+	a.runMu.Lock()
+	defer a.runMu.Unlock()
+
+	if a.IsInputLocked() {
+		return
+	}
+
 	state := a.engine.CurrentState()
 
 	switch state {
 	case timer.Running:
 		a.engine.Pause()
-		a.saveSuspendedRun()
+		a.saveSuspendedRun(false)
 	case timer.Paused:
 		a.engine.Resume()
 	case timer.Idle, timer.Finished:
@@ -160,26 +444,143 @@ func (a *App) TogglePause() {
 	}
 }
 
-// Reset resets the timer. If a run was in progress (not finished), save the incomplete attempt.
+// Reset resets the timer. A run in progress (not finished) is handled by the
+// category's reset policy, which saves the incomplete attempt by default.
+// An active countdown is cancelled instead. Resets of a run in progress go
+// through the configured reset safeguards first.
 func (a *App) Reset() {
+	a.resetWith("")
+}
+
+// ResetWith resets like Reset, but handles a run in progress with the given
+// policy: "save", "golds" or "discard". Returns false for an unknown policy.
+func (a *App) ResetWith(policy string) bool {
+	if a.IsInputLocked() || !split.ResetPolicy(policy).Valid() {
+		return false
+	}
+
+	a.resetWith(split.ResetPolicy(policy))
+
+	return true
+}
+
+// resetWith resets the timer; an empty policy uses the category default.
+func (a *App) resetWith(policy split.ResetPolicy) {
+	a.runMu.Lock()
+	defer a.runMu.Unlock()
+
+	if a.IsInputLocked() || a.cd.Cancel() {
+		return
+	}
+
 	state := a.engine.CurrentState()
 	if state == timer.Idle {
 		return
 	}
 
+	if state != timer.Finished && a.segmentMode == nil && !a.allowReset() {
+		return
+	}
+
 	// Only save an incomplete attempt if the run was in progress.
 	// Finished runs are already saved by checkRunCompletion.
-	if state != timer.Finished {
-		a.saveAttempt(false)
+	// Single-segment runs are only kept once finished.
+	if state != timer.Finished && a.segmentMode == nil {
+		a.finishResetRun(policy)
 	}
 
 	a.engine.Reset()
 	a.deleteSuspendedRun()
 }
 
+// finishResetRun applies a reset policy to the run in progress.
+func (a *App) finishResetRun(policy split.ResetPolicy) {
+	if a.attempts == nil {
+		return
+	}
+
+	if policy == "" {
+		policy = a.attempts.ResetPolicyOrDefault()
+	}
+
+	switch policy {
+	case split.ResetGoldsOnly:
+		a.keepRunGolds()
+	case split.ResetDiscard:
+		// Nothing is kept.
+	default:
+		a.saveAttempt(split.EndReset)
+	}
+}
+
+// keepRunGolds keeps the golds of the run in progress without recording it as an attempt.
+func (a *App) keepRunGolds() {
+	if a.store == nil || (a.runStartSegment > 0 && !a.settings.PartialStartGolds) {
+		return
+	}
+
+	if a.attempts.KeepGolds(a.engine.SplitTimesMS(), a.runHits(), a.runStartSegment) == 0 {
+		return
+	}
+
+	if err := a.store.SaveAttempts(a.attempts); err != nil {
+		fmt.Printf("Warning: could not save attempts: %v\n", err)
+	}
+
+	runtime.EventsEmit(a.ctx, "attempts:updated", a.getAttemptsData())
+}
+
+// allowReset applies the reset safeguards to a run in progress. Every decision
+// is logged and emitted as "reset:safeguard".
+func (a *App) allowReset() bool {
+	guard := a.settings.ResetSafeguards
+	canSetGolds := a.runStartSegment == 0 || a.settings.PartialStartGolds
+
+	var risk string
+	if a.attempts != nil {
+		risk = guard.RunRisk(a.variableView(a.attempts), a.engine.SplitTimesMS(), a.runHits(), a.runStartSegment, canSetGolds)
+	}
+
+	check := split.ResetCheck{Risk: risk, Now: time.Now(), LastSplitAt: a.lastSplitAt, ArmedAt: a.resetArmedAt}
+
+	decision, reason, armedAt := guard.Decide(check, func(risk string) bool {
+		message := "This run is ahead of your personal best. Reset anyway?"
+		if risk == split.RiskGold {
+			message = "This run has a gold. Reset anyway?"
+		}
+
+		return a.ConfirmDialog("Reset Run", message)
+	})
+
+	a.resetArmedAt = armedAt
+	if decision == "" {
+		return true
+	}
+
+	return a.resetDecision(decision, reason)
+}
+
+// resetDecision logs and emits a reset safeguard decision. Returns whether the
+// reset goes ahead.
+func (a *App) resetDecision(decision, reason string) bool {
+	allowed := decision == split.ResetConfirmed
+
+	fmt.Printf("Reset safeguard: %s (%s)\n", decision, reason)
+	runtime.EventsEmit(a.ctx, "reset:safeguard", map[string]any{
+		"decision": decision,
+		"reason":   reason,
+		"allowed":  allowed,
+	})
+
+	return allowed
+}
+
 // DiscardAttempt removes the last completed attempt, recalculates PB, and resets.
 func (a *App) DiscardAttempt() {
-	if a.engine.CurrentState() != timer.Finished {
+	a.runMu.Lock()
+	defer a.runMu.Unlock()
+
+	if a.IsInputLocked() || a.engine.CurrentState() != timer.Finished {
 		return
 	}
 
@@ -202,77 +603,406 @@ func (a *App) DiscardAttempt() {
 
 // UndoSplit undoes the last split.
 func (a *App) UndoSplit() {
+	a.runMu.Lock()
+	defer a.runMu.Unlock()
+
+	if a.IsInputLocked() {
+		return
+	}
+
 	a.engine.UndoSplit()
 	a.emitDeltas()
-	a.saveSuspendedRun()
+	a.saveSuspendedRun(false)
 }
 
 // SkipSplit skips the current segment.
 func (a *App) SkipSplit() {
+	a.runMu.Lock()
+	defer a.runMu.Unlock()
+
+	if a.IsInputLocked() {
+		return
+	}
+
+	if a.engine.CurrentState() == timer.Running {
+		a.lastSplitAt = time.Now()
+	}
+
 	a.engine.SkipSplit()
 	a.emitDeltas()
 	a.checkRunCompletion()
 
 	if a.engine.CurrentState() != timer.Finished {
-		a.saveSuspendedRun()
+		a.saveSuspendedRun(false)
+	}
+}
+
+// Hit counts a hit in the current segment of a hit-counted category.
+func (a *App) Hit() {
+	a.runMu.Lock()
+	defer a.runMu.Unlock()
+
+	if a.IsInputLocked() || a.attempts == nil || !a.attempts.HitCounting || a.segmentMode != nil {
+		return
+	}
+
+	a.engine.Hit()
+	a.emitDeltas()
+	a.saveSuspendedRun(false)
+}
+
+// UndoHit removes a hit from the current segment of a hit-counted category.
+func (a *App) UndoHit() {
+	a.runMu.Lock()
+	defer a.runMu.Unlock()
+
+	if a.IsInputLocked() || a.attempts == nil || !a.attempts.HitCounting || a.segmentMode != nil {
+		return
 	}
+
+	a.engine.UndoHit()
+	a.emitDeltas()
+	a.saveSuspendedRun(false)
 }
 
 func (a *App) emitDeltas() {
-	if a.attempts == nil {
+	if a.attempts == nil || a.segmentMode != nil {
 		return
 	}
 
-	d := split.ComputeSplitDeltas(a.attempts, a.engine.SplitTimesMS(), a.settings.Comparison)
+	splits := a.engine.SplitTimesMS()
+	view := a.variableView(a.attempts)
+	d := a.runDeltas(split.ComputeSplitDeltas(view, splits, a.settings.Comparison))
 	runtime.EventsEmit(a.ctx, "deltas:updated", d)
+
+	if len(a.attempts.Groups()) > 0 {
+		runtime.EventsEmit(a.ctx, "groupDeltas:updated", a.groupDeltas(view, splits))
+	}
+
+	if a.attempts.HitCounting {
+		runtime.EventsEmit(a.ctx, "hitDeltas:updated", a.hitDeltas(view, len(splits)))
+	}
+
+	if ghost := a.attempts.ActiveGhost; ghost != "" {
+		gd := a.runDeltas(split.ComputeSplitDeltas(view, splits, split.GhostComparison(ghost)))
+		runtime.EventsEmit(a.ctx, "ghostDeltas:updated", map[string]any{"ghostId": ghost, "deltas": gd})
+	}
+}
+
+// emitAlerts emits a "pace:alert" event for each alert the latest split triggers.
+func (a *App) emitAlerts() {
+	if a.attempts == nil || a.segmentMode != nil || a.attempts.HitCounting {
+		return
+	}
+
+	alerts := split.ComputeAlerts(a.variableView(a.attempts), a.engine.SplitTimesMS(), a.settings.Alerts)
+	for _, alert := range alerts {
+		runtime.EventsEmit(a.ctx, "pace:alert", alert)
+	}
+}
+
+// hitDeltas computes the hit deltas of the segments passed so far in the current run.
+func (a *App) hitDeltas(view *split.Attempts, passed int) []split.HitDelta {
+	d := split.ComputeHitDeltas(view, a.engine.Hits(), passed, a.settings.Comparison)
+
+	return slices.DeleteFunc(d, func(d split.HitDelta) bool {
+		return d.SegmentIndex < a.runStartSegment
+	})
+}
+
+// runHits returns the current run's hits per segment, or nil if the category is timed.
+func (a *App) runHits() []int {
+	if a.attempts == nil || !a.attempts.HitCounting {
+		return nil
+	}
+
+	return a.engine.Hits()
 }
 
 func (a *App) checkRunCompletion() {
+	if a.engine.CurrentState() == timer.Finished && a.segmentMode != nil {
+		a.finishSegmentRun()
+
+		return
+	}
+
 	if a.engine.CurrentState() == timer.Finished {
-		a.saveAttempt(true)
+		a.saveAttempt(split.EndFinished)
 		a.deleteSuspendedRun()
 	}
 }
 
-func (a *App) saveAttempt(completed bool) {
+// saveAttempt records the engine's current run on the active attempts entry.
+func (a *App) saveAttempt(reason split.EndReason) {
 	if a.attempts == nil || a.store == nil {
 		return
 	}
 
 	splits := a.engine.SplitTimesMS()
-	a.attempts.AddAttempt(splits, completed)
+	completed := reason == split.EndFinished
+
+	segment := a.engine.CurrentSegment()
+	if completed {
+		segment = max(len(splits)-1, 0)
+	}
+
+	end := split.AttemptEnd{
+		Reason:    reason,
+		Segment:   segment,
+		StartedAt: a.runStartedAt,
+		EndedAt:   time.Now(),
+		ElapsedMS: a.engine.ElapsedMS(),
+		PausedMS:  a.engine.PausedMS(),
+
+		StartSegment: a.runStartSegment,
+		ExcludeGolds: a.runStartSegment > 0 && !a.settings.PartialStartGolds,
+		Hits:         a.runHits(),
+	}
+
+	a.attempts.RecordAttempt(splits, completed, end)
 
 	if err := a.store.SaveAttempts(a.attempts); err != nil {
 		fmt.Printf("Warning: could not save attempts: %v\n", err)
 	}
 
+	a.creditMarathonLegs(a.attempts, splits, completed, end)
+
 	runtime.EventsEmit(a.ctx, "attempts:updated", a.getAttemptsData())
 }
 
-// CreateTemplate creates a new template and returns its data.
-func (a *App) CreateTemplate(name string, segmentNames []string) map[string]any {
-	id := uuid.New().String()
-	tmpl := split.NewTemplate(id, name, segmentNames)
-	a.tmpl = tmpl
+// StartILMode switches the timer to individual level runs of one segment of the
+// active attempts entry. Each start/split times that segment alone and records it
+// as an IL record; full-run history is not touched. While a run is timed,
+// "segmentMode:delta" events compare it with the IL best and the gold. Only
+// allowed while idle.
+func (a *App) StartILMode(segmentIndex int) map[string]any {
+	return a.startSegmentMode(persist.ILLog, segmentIndex)
+}
 
-	if a.store != nil {
-		if err := a.store.SaveTemplate(tmpl); err != nil {
-			fmt.Printf("Warning: could not save template: %v\n", err)
-		}
+// StartPracticeMode switches the timer to repeated practice of one segment of the
+// active attempts entry. Runs go to the practice log and never change attempt
+// history, PB or golds. Only allowed while idle.
+func (a *App) StartPracticeMode(segmentIndex int) map[string]any {
+	return a.startSegmentMode(persist.PracticeLog, segmentIndex)
+}
+
+// StopSegmentMode returns the timer to full runs, dropping any unfinished single-segment run.
+func (a *App) StopSegmentMode() bool {
+	if a.IsInputLocked() || a.segmentMode == nil || a.attempts == nil {
+		return false
 	}
 
-	return a.getTemplateData()
+	a.engine.Reset()
+	a.activateSegments(a.attempts)
+	runtime.EventsEmit(a.ctx, "segmentMode:changed", nil)
+
+	return true
 }
 
-// LoadTemplate loads a template by ID and sets it as the current template.
-func (a *App) LoadTemplate(id string) map[string]any {
+// GetSegmentMode returns the active single-segment mode, or nil during full runs.
+func (a *App) GetSegmentMode() map[string]any {
+	return a.segmentMode.data()
+}
+
+// GetILLeaderboard returns the best IL times of each segment of an attempts entry,
+// at most top per segment (all if top <= 0).
+func (a *App) GetILLeaderboard(attemptsID string, top int) []split.ILStanding {
 	if a.store == nil {
 		return nil
 	}
 
-	tmpl, err := a.store.LoadTemplate(id)
+	att, err := a.store.LoadAttempts(attemptsID)
 	if err != nil {
-		fmt.Printf("Warning: could not load template: %v\n", err)
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	log, err := a.store.LoadSegmentLog(persist.ILLog, attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load IL log: %v\n", err)
+
+		return nil
+	}
+
+	return log.Leaderboard(att.Segments, top)
+}
+
+// GetPracticeSummary returns practice totals for each segment of an attempts entry.
+func (a *App) GetPracticeSummary(attemptsID string) []split.PracticeSummary {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	log, err := a.store.LoadSegmentLog(persist.PracticeLog, attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load practice log: %v\n", err)
+
+		return nil
+	}
+
+	return log.Summaries(att.Segments)
+}
+
+func (a *App) startSegmentMode(kind persist.SegmentLogKind, index int) map[string]any {
+	if a.IsInputLocked() || a.attempts == nil || a.engine.CurrentState() != timer.Idle {
+		return nil
+	}
+
+	if index < 0 || index >= len(a.attempts.Segments) {
+		return nil
+	}
+
+	seg := a.attempts.Segments[index]
+	a.segmentMode = &segmentMode{kind: kind, index: index, id: seg.ID, name: seg.Name}
+	a.segmentMode.goldMS = a.attempts.BestSegments()[index]
+
+	if kind == persist.ILLog && a.store != nil {
+		if log, err := a.store.LoadSegmentLog(persist.ILLog, a.attempts.ID); err == nil {
+			if best, ok := log.Best(seg.ID); ok {
+				a.segmentMode.ilBestMS = best.TimeMS
+			}
+		}
+	}
+
+	a.engine.SetSegments([]string{a.segmentMode.name})
+
+	data := a.segmentMode.data()
+	runtime.EventsEmit(a.ctx, "segmentMode:changed", data)
+
+	return data
+}
+
+// finishSegmentRun records a finished single-segment run and readies the timer
+// for the next one. Skipped runs have no time and are dropped.
+func (a *App) finishSegmentRun() {
+	splits := a.engine.SplitTimesMS()
+	a.engine.Reset()
+
+	if len(splits) == 0 || splits[0] == 0 {
+		return
+	}
+
+	a.recordSegmentRun(splits[0])
+}
+
+// recordSegmentRun adds a finished single-segment run to the log of the mode's
+// kind and emits how it compares, as "il:result" or "practice:result".
+func (a *App) recordSegmentRun(timeMS int64) {
+	if a.store == nil {
+		return
+	}
+
+	m := a.segmentMode
+
+	log, err := a.store.LoadSegmentLog(m.kind, a.attempts.ID)
+	if err != nil {
+		fmt.Printf("Warning: could not load %s log: %v\n", m.kind, err)
+
+		return
+	}
+
+	var (
+		event  string
+		result any
+		isBest bool
+	)
+
+	switch m.kind {
+	case persist.ILLog:
+		res := log.RecordIL(m.id, timeMS, time.Now())
+		event, result, isBest = "il:result", map[string]any{"result": res, "goldMs": m.goldMS}, res.IsBest
+	case persist.PracticeLog:
+		event, result = "practice:result", log.RecordPractice(m.id, timeMS, m.goldMS, time.Now())
+	}
+
+	if err := a.store.SaveSegmentLog(m.kind, log); err != nil {
+		fmt.Printf("Warning: could not save %s log: %v\n", m.kind, err)
+
+		return
+	}
+
+	if isBest {
+		m.ilBestMS = timeMS
+	}
+
+	runtime.EventsEmit(a.ctx, event, result)
+}
+
+// activateSegments loads att's segments into the engine, leaving any single-segment mode.
+func (a *App) activateSegments(att *split.Attempts) {
+	a.segmentMode = nil
+	a.engine.SetSegments(att.SegmentNames())
+}
+
+// CreateTemplate creates a new template and returns its data.
+func (a *App) CreateTemplate(name string, segmentNames []string) map[string]any {
+	id := uuid.New().String()
+	tmpl := split.NewTemplate(id, name, segmentNames)
+	a.tmpl = tmpl
+
+	if a.store != nil {
+		if err := a.store.SaveTemplate(tmpl); err != nil {
+			fmt.Printf("Warning: could not save template: %v\n", err)
+		}
+	}
+
+	return a.getTemplateData()
+}
+
+// CreateMarathon chains attempts entries, possibly of different games, into a new
+// marathon template and sets it as the current template. Attempts of a category
+// created from it are also recorded on each chained entry.
+func (a *App) CreateMarathon(name string, attemptsIDs []string) map[string]any {
+	if a.store == nil || len(attemptsIDs) == 0 {
+		return nil
+	}
+
+	legs := make([]*split.Attempts, len(attemptsIDs))
+	legNames := make([]string, len(attemptsIDs))
+
+	for i, id := range attemptsIDs {
+		att, err := a.store.LoadAttempts(id)
+		if err != nil {
+			fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+			return nil
+		}
+
+		legs[i] = att
+		legNames[i] = att.CategoryName
+
+		if tmpl, err := a.store.LoadTemplate(att.TemplateID); err == nil {
+			legNames[i] = tmpl.Name
+		}
+	}
+
+	tmpl := split.NewMarathonTemplate(uuid.New().String(), name, legs, legNames)
+	a.tmpl = tmpl
+
+	if err := a.store.SaveTemplate(tmpl); err != nil {
+		fmt.Printf("Warning: could not save template: %v\n", err)
+	}
+
+	return a.getTemplateData()
+}
+
+// LoadTemplate loads a template by ID and sets it as the current template.
+func (a *App) LoadTemplate(id string) map[string]any {
+	if a.store == nil {
+		return nil
+	}
+
+	tmpl, err := a.store.LoadTemplate(id)
+	if err != nil {
+		fmt.Printf("Warning: could not load template: %v\n", err)
 
 		return nil
 	}
@@ -298,6 +1028,40 @@ func (a *App) ListTemplates() []persist.TemplateSummary {
 	return summaries
 }
 
+// DuplicateTemplate copies a template under a new name. categoryMode selects what
+// history its attempts entries keep ("history", "none", "golds" or "pb"); an
+// empty mode copies the template alone.
+func (a *App) DuplicateTemplate(id, name, categoryMode string) map[string]any {
+	if a.store == nil {
+		return nil
+	}
+
+	tmpl, err := a.store.DuplicateTemplate(id, name, split.CopyMode(categoryMode), uuid.NewString)
+	if err != nil {
+		fmt.Printf("Warning: could not duplicate template: %v\n", err)
+
+		return nil
+	}
+
+	return templateData(tmpl)
+}
+
+// SearchTemplates returns the saved templates whose game metadata matches query.
+func (a *App) SearchTemplates(query persist.TemplateQuery) []persist.TemplateSummary {
+	if a.store == nil {
+		return nil
+	}
+
+	summaries, err := a.store.SearchTemplates(query)
+	if err != nil {
+		fmt.Printf("Warning: could not search templates: %v\n", err)
+
+		return nil
+	}
+
+	return summaries
+}
+
 // DeleteTemplate deletes a template and its associated attempts.
 func (a *App) DeleteTemplate(id string) bool {
 	if a.store == nil {
@@ -310,6 +1074,11 @@ func (a *App) DeleteTemplate(id string) bool {
 		return false
 	}
 
+	// The template is gone either way; leftover images are retried on the next prune.
+	if err := a.store.PruneAssets(); err != nil {
+		fmt.Printf("Warning: could not prune assets: %v\n", err)
+	}
+
 	if a.tmpl != nil && a.tmpl.ID == id {
 		a.tmpl = nil
 		a.attempts = nil
@@ -318,6 +1087,28 @@ func (a *App) DeleteTemplate(id string) bool {
 	return true
 }
 
+// TemplateProfiles returns the names of the other runner profiles with
+// categories on a template. DeleteTemplate refuses while there are any.
+func (a *App) TemplateProfiles(id string) []string {
+	if a.store == nil {
+		return nil
+	}
+
+	profiles, err := a.store.ProfilesUsingTemplate(id)
+	if err != nil {
+		fmt.Printf("Warning: could not list profiles using template: %v\n", err)
+
+		return nil
+	}
+
+	names := make([]string, len(profiles))
+	for i, p := range profiles {
+		names[i] = p.Name
+	}
+
+	return names
+}
+
 // UpdateTemplate updates a template's name and segment names.
 func (a *App) UpdateTemplate(id, name string, segmentNames []string) map[string]any {
 	if a.store == nil {
@@ -332,8 +1123,7 @@ func (a *App) UpdateTemplate(id, name string, segmentNames []string) map[string]
 	}
 
 	tmpl.Name = name
-	tmpl.SegmentNames = segmentNames
-	tmpl.UpdatedAt = time.Now()
+	tmpl.SetSegmentNames(segmentNames)
 
 	if err := a.store.SaveTemplate(tmpl); err != nil {
 		fmt.Printf("Warning: could not save template: %v\n", err)
@@ -345,94 +1135,1176 @@ func (a *App) UpdateTemplate(id, name string, segmentNames []string) map[string]
 		a.tmpl = tmpl
 	}
 
-	return map[string]any{
-		"id":           tmpl.ID,
-		"name":         tmpl.Name,
-		"segmentNames": tmpl.SegmentNames,
+	return templateData(tmpl)
+}
+
+// UpdateTemplateGroups sets the subsplit group of each template segment.
+// An empty name leaves a segment ungrouped.
+func (a *App) UpdateTemplateGroups(id string, groups []string) map[string]any {
+	return a.editTemplate(id, func(tmpl *split.Template) bool {
+		return tmpl.SetSegmentGroups(groups)
+	})
+}
+
+// UpdateTemplateSegmentNotes sets the markdown notes of one template segment.
+// Categories show these for segments without notes of their own.
+func (a *App) UpdateTemplateSegmentNotes(id string, index int, notes string) map[string]any {
+	return a.editTemplate(id, func(tmpl *split.Template) bool {
+		return tmpl.SetSegmentNotes(index, notes)
+	})
+}
+
+// UpdateTemplateGame replaces a template's game metadata.
+func (a *App) UpdateTemplateGame(id string, game split.GameInfo) map[string]any {
+	return a.editTemplate(id, func(tmpl *split.Template) bool {
+		tmpl.Game = game
+		tmpl.UpdatedAt = time.Now()
+
+		return true
+	})
+}
+
+// UpdateTemplateVariables replaces a template's sub-category variables.
+func (a *App) UpdateTemplateVariables(id string, vars []split.Variable) map[string]any {
+	return a.editTemplate(id, func(tmpl *split.Template) bool {
+		return tmpl.SetVariables(vars)
+	})
+}
+
+// ChooseSegmentIcon asks the user for an image and uses it as the icon of one
+// template segment. Returns nil if the dialog is cancelled.
+func (a *App) ChooseSegmentIcon(id string, index int) map[string]any {
+	assetID := a.chooseImage("Choose Segment Icon")
+	if assetID == "" {
+		return nil
+	}
+
+	return a.editTemplate(id, func(tmpl *split.Template) bool {
+		return tmpl.SetSegmentIcon(index, assetID)
+	})
+}
+
+// ClearSegmentIcon removes the icon of one template segment.
+func (a *App) ClearSegmentIcon(id string, index int) map[string]any {
+	return a.editTemplate(id, func(tmpl *split.Template) bool {
+		return tmpl.SetSegmentIcon(index, "")
+	})
+}
+
+// ChooseTemplateCover asks the user for an image and uses it as the template's
+// cover. Returns nil if the dialog is cancelled.
+func (a *App) ChooseTemplateCover(id string) map[string]any {
+	assetID := a.chooseImage("Choose Cover Image")
+	if assetID == "" {
+		return nil
 	}
+
+	return a.editTemplate(id, func(tmpl *split.Template) bool {
+		tmpl.CoverImage = assetID
+		tmpl.UpdatedAt = time.Now()
+
+		return true
+	})
 }
 
-// CreateAttempts creates a new attempts entry for the current template.
-func (a *App) CreateAttempts(templateID, name, categoryName string) map[string]any {
+// ClearTemplateCover removes the template's cover image.
+func (a *App) ClearTemplateCover(id string) map[string]any {
+	return a.editTemplate(id, func(tmpl *split.Template) bool {
+		tmpl.CoverImage = ""
+		tmpl.UpdatedAt = time.Now()
+
+		return true
+	})
+}
+
+// ExportTemplate writes a template with its attempts and images to a file the
+// user picks. Returns false if the dialog is cancelled or the export fails.
+func (a *App) ExportTemplate(id string) bool {
+	if a.store == nil {
+		return false
+	}
+
+	bundle, err := a.store.ExportBundle(id)
+	if err != nil {
+		fmt.Printf("Warning: could not export template: %v\n", err)
+
+		return false
+	}
+
+	path, err := runtime.SaveFileDialog(a.ctx, runtime.SaveDialogOptions{
+		Title:           "Export Template",
+		DefaultFilename: bundle.Template.Name + ".goldsplit.json",
+		Filters:         []runtime.FileFilter{{DisplayName: "Goldsplit Export", Pattern: "*.json"}},
+	})
+	if err != nil || path == "" {
+		return false
+	}
+
+	data, err := persist.MarshalBundle(bundle)
+	if err != nil {
+		fmt.Printf("Warning: could not export template: %v\n", err)
+
+		return false
+	}
+
+	if err := os.WriteFile(path, data, 0o640); err != nil {
+		fmt.Printf("Warning: could not write export file: %v\n", err)
+
+		return false
+	}
+
+	return true
+}
+
+// ImportTemplate reads an export file the user picks and adds its template,
+// attempts and images as new entries. Returns nil if the dialog is cancelled.
+func (a *App) ImportTemplate() map[string]any {
 	if a.store == nil {
 		return nil
 	}
 
-	tmpl, err := a.store.LoadTemplate(templateID)
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title:   "Import Template",
+		Filters: []runtime.FileFilter{{DisplayName: "Goldsplit Export", Pattern: "*.json"}},
+	})
+	if err != nil || path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Printf("Warning: could not read import file: %v\n", err)
+
+		return nil
+	}
+
+	bundle, err := persist.UnmarshalBundle(data)
+	if err != nil {
+		fmt.Printf("Warning: could not import template: %v\n", err)
+
+		return nil
+	}
+
+	tmpl, err := a.store.ImportBundle(bundle, uuid.NewString)
+	if err != nil {
+		fmt.Printf("Warning: could not import template: %v\n", err)
+
+		return nil
+	}
+
+	return templateData(tmpl)
+}
+
+// editTemplate loads a template, applies edit and saves it. Images the edit
+// stopped using are removed. Returns nil if edit returns false.
+func (a *App) editTemplate(id string, edit func(*split.Template) bool) map[string]any {
+	if a.store == nil {
+		return nil
+	}
+
+	tmpl, err := a.store.LoadTemplate(id)
 	if err != nil {
 		fmt.Printf("Warning: could not load template: %v\n", err)
 
 		return nil
 	}
 
-	id := uuid.New().String()
-	att := split.NewAttempts(id, templateID, name, categoryName, tmpl.SegmentNames)
-	a.attempts = att
-	a.engine.SetSegments(att.SegmentNames())
+	if !edit(tmpl) {
+		return nil
+	}
 
-	if err := a.store.SaveAttempts(att); err != nil {
-		fmt.Printf("Warning: could not save attempts: %v\n", err)
+	if err := a.store.SaveTemplate(tmpl); err != nil {
+		fmt.Printf("Warning: could not save template: %v\n", err)
+
+		return nil
+	}
+
+	if err := a.store.PruneAssets(); err != nil {
+		fmt.Printf("Warning: could not prune assets: %v\n", err)
+	}
+
+	if a.tmpl != nil && a.tmpl.ID == id {
+		a.tmpl = tmpl
+	}
+
+	return templateData(tmpl)
+}
+
+// chooseImage opens a file dialog and stores the picked image as an asset.
+// Returns the asset ID, or "" if cancelled or the file could not be stored.
+func (a *App) chooseImage(title string) string {
+	if a.store == nil {
+		return ""
+	}
+
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title: title,
+		Filters: []runtime.FileFilter{{
+			DisplayName: "Images",
+			Pattern:     "*.png;*.jpg;*.jpeg;*.gif;*.webp",
+		}},
+	})
+	if err != nil || path == "" {
+		return ""
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Printf("Warning: could not read image: %v\n", err)
+
+		return ""
+	}
+
+	assetID, err := a.store.SaveAsset(data, filepath.Ext(path))
+	if err != nil {
+		fmt.Printf("Warning: could not save image: %v\n", err)
+
+		return ""
+	}
+
+	return assetID
+}
+
+// serveAsset serves stored images to the frontend under persist.AssetURLPrefix.
+// Asset IDs are content hashes, so responses never change and can be cached.
+func (a *App) serveAsset(w http.ResponseWriter, r *http.Request) {
+	id, ok := strings.CutPrefix(r.URL.Path, persist.AssetURLPrefix)
+	if !ok || a.store == nil {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	data, err := a.store.LoadAsset(id)
+	if err != nil {
+		http.NotFound(w, r)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", mime.TypeByExtension(filepath.Ext(id)))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	_, _ = w.Write(data)
+}
+
+// UpdateCategorySegmentNotes sets the markdown notes of one segment in an attempts entry.
+func (a *App) UpdateCategorySegmentNotes(attemptsID string, index int, notes string) map[string]any {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	if !att.SetSegmentNotes(index, notes) {
+		return nil
+	}
+
+	if err := a.store.SaveAttempts(att); err != nil {
+		fmt.Printf("Warning: could not save attempts: %v\n", err)
+
+		return nil
+	}
+
+	if a.attempts != nil && a.attempts.ID == attemptsID {
+		a.attempts = att
+	}
+
+	return a.buildAttemptsData(att)
+}
+
+// GetSegmentNotes returns the notes for each segment of an attempts entry,
+// including notes inherited from its template.
+func (a *App) GetSegmentNotes(attemptsID string) []string {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	tmpl, err := a.store.LoadTemplate(att.TemplateID)
+	if err != nil {
+		tmpl = nil
+	}
+
+	return att.SegmentNotes(tmpl)
+}
+
+// SetCategoryVariables sets the variable values recorded with new attempts in an
+// attempts entry. PB, golds and comparisons then only use attempts with the same values.
+// Returns nil if a name or value is not one of the template's variables.
+func (a *App) SetCategoryVariables(attemptsID string, values map[string]string) map[string]any {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	tmpl, err := a.store.LoadTemplate(att.TemplateID)
+	if err != nil {
+		fmt.Printf("Warning: could not load template: %v\n", err)
+
+		return nil
+	}
+
+	if !att.SetVariableValues(tmpl.Variables, values) {
+		return nil
+	}
+
+	if err := a.store.SaveAttempts(att); err != nil {
+		fmt.Printf("Warning: could not save attempts: %v\n", err)
+
+		return nil
+	}
+
+	if a.attempts != nil && a.attempts.ID == attemptsID {
+		a.attempts = att
+		a.emitDeltas()
+	}
+
+	return a.buildAttemptsData(att)
+}
+
+// SetCategoryHitCounting switches an attempts entry between timed and no-hit runs.
+// The active entry can only be switched while the timer is idle.
+func (a *App) SetCategoryHitCounting(attemptsID string, enabled bool) map[string]any {
+	if a.store == nil || !a.canEditSegments(attemptsID) {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	att.SetHitCounting(enabled)
+
+	if err := a.store.SaveAttempts(att); err != nil {
+		fmt.Printf("Warning: could not save attempts: %v\n", err)
+
+		return nil
+	}
+
+	if a.attempts != nil && a.attempts.ID == attemptsID {
+		a.attempts = att
+		runtime.EventsEmit(a.ctx, "attempts:updated", a.getAttemptsData())
+	}
+
+	return a.buildAttemptsData(att)
+}
+
+// SetCategoryResetPolicy sets what a reset run of an attempts entry contributes
+// by default: "save", "golds" or "discard". An empty policy restores saving.
+func (a *App) SetCategoryResetPolicy(attemptsID, policy string) map[string]any {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	if !att.SetResetPolicy(split.ResetPolicy(policy)) {
+		return nil
+	}
+
+	if err := a.store.SaveAttempts(att); err != nil {
+		fmt.Printf("Warning: could not save attempts: %v\n", err)
+
+		return nil
+	}
+
+	if a.attempts != nil && a.attempts.ID == attemptsID {
+		a.attempts = att
+		runtime.EventsEmit(a.ctx, "attempts:updated", a.getAttemptsData())
+	}
+
+	return a.buildAttemptsData(att)
+}
+
+// SetAttemptVariables replaces the variable values of a single recorded attempt.
+// Returns nil if a name or value is not one of the template's variables.
+func (a *App) SetAttemptVariables(attemptsID string, attemptID int, values map[string]string) map[string]any {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	tmpl, err := a.store.LoadTemplate(att.TemplateID)
+	if err != nil {
+		fmt.Printf("Warning: could not load template: %v\n", err)
+
+		return nil
+	}
+
+	if !att.SetAttemptVariables(tmpl.Variables, attemptID, values) {
+		return nil
+	}
+
+	if err := a.store.SaveAttempts(att); err != nil {
+		fmt.Printf("Warning: could not save attempts: %v\n", err)
+
+		return nil
+	}
+
+	if a.attempts != nil && a.attempts.ID == attemptsID {
+		a.attempts = att
+	}
+
+	return a.buildAttemptsData(att)
+}
+
+// GetVariableView returns attempts data computed only from attempts matching
+// values, without changing the entry's current values. Empty values match anything.
+func (a *App) GetVariableView(attemptsID string, values map[string]string) map[string]any {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	view := att.Clone()
+	view.VariableValues = values
+
+	return a.buildAttemptsData(view)
+}
+
+// AddGhost adds another runner's cumulative splits to an attempts entry as a
+// ghost to race. The ghost is kept apart from the entry's own attempts.
+func (a *App) AddGhost(attemptsID, name, source string, splitTimesMS []int64) map[string]any {
+	return a.editGhosts(attemptsID, func(att *split.Attempts) bool {
+		return att.AddGhost(split.Ghost{
+			ID:           uuid.NewString(),
+			Name:         name,
+			Source:       source,
+			SplitTimesMS: splitTimesMS,
+			AddedAt:      time.Now(),
+		})
+	})
+}
+
+// ImportGhost turns the PB of an export file the user picks into a ghost. The
+// file's category with the same name is preferred; segments are matched by name.
+// Returns nil if the dialog is cancelled or the file has no usable run.
+func (a *App) ImportGhost(attemptsID, name string) map[string]any {
+	if a.store == nil {
+		return nil
+	}
+
+	path, err := runtime.OpenFileDialog(a.ctx, runtime.OpenDialogOptions{
+		Title:   "Import Ghost",
+		Filters: []runtime.FileFilter{{DisplayName: "Goldsplit Export", Pattern: "*.json"}},
+	})
+	if err != nil || path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Printf("Warning: could not read import file: %v\n", err)
+
+		return nil
+	}
+
+	bundle, err := persist.UnmarshalBundle(data)
+	if err != nil {
+		fmt.Printf("Warning: could not import ghost: %v\n", err)
+
+		return nil
+	}
+
+	return a.editGhosts(attemptsID, func(att *split.Attempts) bool {
+		for _, sameCategory := range []bool{true, false} {
+			for _, other := range bundle.Attempts {
+				if (other.CategoryName == att.CategoryName) != sameCategory {
+					continue
+				}
+
+				if g, ok := att.GhostFrom(other, uuid.NewString(), name, filepath.Base(path)); ok {
+					return att.AddGhost(g)
+				}
+			}
+		}
+
+		return false
+	})
+}
+
+// RemoveGhost deletes a ghost from an attempts entry.
+func (a *App) RemoveGhost(attemptsID, ghostID string) map[string]any {
+	return a.editGhosts(attemptsID, func(att *split.Attempts) bool {
+		return att.RemoveGhost(ghostID)
+	})
+}
+
+// SetActiveGhost picks the ghost whose deltas are emitted alongside the normal
+// comparison. An empty ID stops racing a ghost.
+func (a *App) SetActiveGhost(attemptsID, ghostID string) map[string]any {
+	return a.editGhosts(attemptsID, func(att *split.Attempts) bool {
+		return att.SetActiveGhost(ghostID)
+	})
+}
+
+// editGhosts loads an attempts entry, applies edit and saves it. Returns nil if
+// edit returns false.
+func (a *App) editGhosts(attemptsID string, edit func(*split.Attempts) bool) map[string]any {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	if !edit(att) {
+		return nil
+	}
+
+	if err := a.store.SaveAttempts(att); err != nil {
+		fmt.Printf("Warning: could not save attempts: %v\n", err)
+
+		return nil
+	}
+
+	if a.attempts != nil && a.attempts.ID == attemptsID {
+		a.attempts = att
+		a.emitDeltas()
+		runtime.EventsEmit(a.ctx, "attempts:updated", a.getAttemptsData())
+	}
+
+	return a.buildAttemptsData(att)
+}
+
+// UpdateCategoryGroups sets the subsplit group of each segment in an attempts entry.
+func (a *App) UpdateCategoryGroups(attemptsID string, groups []string) map[string]any {
+	return a.editSegments(attemptsID, func(att *split.Attempts) bool {
+		return att.SetSegmentGroups(groups)
+	})
+}
+
+// SuggestCategorySync proposes a segment mapping from an attempts entry's segments to
+// its template's current segments, matching by name. See SyncCategoryToTemplate.
+func (a *App) SuggestCategorySync(attemptsID string) [][]int {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	tmpl, err := a.store.LoadTemplate(att.TemplateID)
+	if err != nil {
+		fmt.Printf("Warning: could not load template: %v\n", err)
+
+		return nil
+	}
+
+	return split.SuggestSegmentSources(att.SegmentNames(), tmpl.SegmentNames)
+}
+
+// SyncCategoryToTemplate replaces an attempts entry's segments with its template's
+// current segments and rewrites its history to match. sources[j] lists the entry's
+// existing segment indices that make up template segment j (see split.Attempts.RemapSegments).
+// The sync can be reverted with UndoSegmentEdit.
+func (a *App) SyncCategoryToTemplate(attemptsID string, sources [][]int) map[string]any {
+	return a.editSegments(attemptsID, func(att *split.Attempts) bool {
+		tmpl, err := a.store.LoadTemplate(att.TemplateID)
+		if err != nil {
+			fmt.Printf("Warning: could not load template: %v\n", err)
+
+			return false
+		}
+
+		if !att.RemapSegments(tmpl.SegmentNames, sources) {
+			return false
+		}
+
+		if len(tmpl.SegmentGroups) > 0 {
+			att.SetSegmentGroups(tmpl.SegmentGroups)
+		}
+
+		return true
+	})
+}
+
+// InsertSegment inserts a segment with no recorded times into an attempts entry.
+func (a *App) InsertSegment(attemptsID string, index int, name string) map[string]any {
+	return a.editSegments(attemptsID, func(att *split.Attempts) bool {
+		return att.InsertSegment(index, name)
+	})
+}
+
+// RemoveSegment removes a segment from an attempts entry, folding its time into the next one.
+func (a *App) RemoveSegment(attemptsID string, index int) map[string]any {
+	return a.editSegments(attemptsID, func(att *split.Attempts) bool {
+		return att.RemoveSegment(index)
+	})
+}
+
+// MergeSegments merges a segment with the one after it.
+func (a *App) MergeSegments(attemptsID string, index int, name string) map[string]any {
+	return a.editSegments(attemptsID, func(att *split.Attempts) bool {
+		return att.MergeSegments(index, name)
+	})
+}
+
+// SplitSegment splits a segment in two, leaving the new boundary unknown.
+func (a *App) SplitSegment(attemptsID string, index int, firstName, secondName string) map[string]any {
+	return a.editSegments(attemptsID, func(att *split.Attempts) bool {
+		return att.SplitSegment(index, firstName, secondName)
+	})
+}
+
+// MoveSegment moves a segment to a new position.
+func (a *App) MoveSegment(attemptsID string, from, to int) map[string]any {
+	return a.editSegments(attemptsID, func(att *split.Attempts) bool {
+		return att.MoveSegment(from, to)
+	})
+}
+
+// UndoSegmentEdit restores an attempts entry to how it was before its last segment edit.
+// Fails if the entry has changed in any other way since that edit.
+func (a *App) UndoSegmentEdit(attemptsID string) map[string]any {
+	stack := a.segmentUndo[attemptsID]
+	if a.store == nil || len(stack) == 0 || !a.canEditSegments(attemptsID) {
+		return nil
+	}
+
+	last := stack[len(stack)-1]
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	if !att.UpdatedAt.Equal(last.updatedAt) {
+		delete(a.segmentUndo, attemptsID)
+
+		return nil
+	}
+
+	restored := last.before
+	restored.UpdatedAt = time.Now()
+	// IDs handed out since the snapshot may already have IL or practice runs.
+	restored.LastSegmentID = max(restored.LastSegmentID, att.LastSegmentID)
+
+	if err := a.store.SaveAttempts(restored); err != nil {
+		fmt.Printf("Warning: could not save attempts: %v\n", err)
+
+		return nil
+	}
+
+	a.segmentUndo[attemptsID] = stack[:len(stack)-1]
+	if len(stack) > 1 {
+		// The next snapshot down was taken against the state just restored.
+		a.segmentUndo[attemptsID][len(stack)-2].updatedAt = restored.UpdatedAt
+	}
+
+	a.applySegmentEdit(restored)
+
+	return a.buildAttemptsData(restored)
+}
+
+// editSegments applies a structural segment edit to an attempts entry and records an undo snapshot.
+func (a *App) editSegments(attemptsID string, edit func(*split.Attempts) bool) map[string]any {
+	if a.store == nil || !a.canEditSegments(attemptsID) {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	before := att.Clone()
+	if !edit(att) {
+		return nil
+	}
+
+	if err := a.store.SaveAttempts(att); err != nil {
+		fmt.Printf("Warning: could not save attempts: %v\n", err)
+
+		return nil
+	}
+
+	if a.segmentUndo == nil {
+		a.segmentUndo = make(map[string][]segmentEdit)
+	}
+
+	stack := append(a.segmentUndo[attemptsID], segmentEdit{before: before, updatedAt: att.UpdatedAt})
+	if len(stack) > maxSegmentUndo {
+		stack = stack[len(stack)-maxSegmentUndo:]
+	}
+
+	a.segmentUndo[attemptsID] = stack
+	a.applySegmentEdit(att)
+
+	return a.buildAttemptsData(att)
+}
+
+// canEditSegments reports whether an attempts entry's segments may change:
+// the active entry can only be edited while the timer is idle.
+func (a *App) canEditSegments(attemptsID string) bool {
+	if a.attempts == nil || a.attempts.ID != attemptsID {
+		return true
+	}
+
+	return a.engine.CurrentState() == timer.Idle
+}
+
+// applySegmentEdit makes an edited entry active again if it was the active one.
+func (a *App) applySegmentEdit(att *split.Attempts) {
+	if a.attempts == nil || a.attempts.ID != att.ID {
+		return
+	}
+
+	a.attempts = att
+	a.activateSegments(att)
+	runtime.EventsEmit(a.ctx, "attempts:updated", a.getAttemptsData())
+}
+
+// CreateAttempts creates a new attempts entry for the current template.
+func (a *App) CreateAttempts(templateID, name, categoryName string) map[string]any {
+	if a.store == nil {
+		return nil
+	}
+
+	tmpl, err := a.store.LoadTemplate(templateID)
+	if err != nil {
+		fmt.Printf("Warning: could not load template: %v\n", err)
+
+		return nil
+	}
+
+	id := uuid.New().String()
+	att := split.NewAttempts(id, templateID, name, categoryName, tmpl.SegmentNames)
+	if len(tmpl.SegmentGroups) > 0 {
+		att.SetSegmentGroups(tmpl.SegmentGroups)
+	}
+
+	a.attempts = att
+	a.activateSegments(att)
+
+	if err := a.store.SaveAttempts(att); err != nil {
+		fmt.Printf("Warning: could not save attempts: %v\n", err)
+	}
+
+	return a.getAttemptsData()
+}
+
+// DuplicateAttempts copies an attempts entry within its template under a new name.
+// mode is "history", "none", "golds" or "pb" and selects what history comes along.
+func (a *App) DuplicateAttempts(id, name, categoryName, mode string) map[string]any {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.DuplicateAttempts(id, "", name, categoryName, split.CopyMode(mode), uuid.NewString)
+	if err != nil {
+		fmt.Printf("Warning: could not duplicate attempts: %v\n", err)
+
+		return nil
+	}
+
+	return a.buildAttemptsData(att)
+}
+
+// LoadAttempts loads attempts by ID and sets them as active.
+func (a *App) LoadAttempts(id string) map[string]any {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(id)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	a.attempts = att
+	a.activateSegments(att)
+
+	return a.getAttemptsData()
+}
+
+// ListAttemptsForTemplate returns all attempts for a given template.
+func (a *App) ListAttemptsForTemplate(templateID string) []persist.AttemptsSummary {
+	if a.store == nil {
+		return nil
+	}
+
+	summaries, err := a.store.ListAttemptsForTemplate(templateID)
+	if err != nil {
+		fmt.Printf("Warning: could not list attempts: %v\n", err)
+
+		return nil
+	}
+
+	return summaries
+}
+
+// DeleteAttempts deletes an attempts entry by ID.
+func (a *App) DeleteAttempts(id string) bool {
+	if a.store == nil {
+		return false
+	}
+
+	if err := a.store.DeleteAttempts(id); err != nil {
+		fmt.Printf("Warning: could not delete attempts: %v\n", err)
+
+		return false
+	}
+
+	if a.attempts != nil && a.attempts.ID == id {
+		a.attempts = nil
+	}
+
+	return true
+}
+
+// UpdateCategoryName renames a category on an attempts entry.
+func (a *App) UpdateCategoryName(attemptsID, newName string) map[string]any {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	att.CategoryName = newName
+	att.UpdatedAt = time.Now()
+
+	if err := a.store.SaveAttempts(att); err != nil {
+		fmt.Printf("Warning: could not save attempts: %v\n", err)
+
+		return nil
+	}
+
+	if a.attempts != nil && a.attempts.ID == attemptsID {
+		a.attempts = att
+	}
+
+	return a.buildAttemptsData(att)
+}
+
+// DeleteSingleAttempt removes a single attempt from an attempts entry.
+func (a *App) DeleteSingleAttempt(attemptsID string, attemptID int) map[string]any {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	if !att.DeleteAttempt(attemptID) {
+		return nil
+	}
+
+	if err := a.store.SaveAttempts(att); err != nil {
+		fmt.Printf("Warning: could not save attempts: %v\n", err)
+
+		return nil
+	}
+
+	if a.attempts != nil && a.attempts.ID == attemptsID {
+		a.attempts = att
+	}
+
+	return a.buildAttemptsData(att)
+}
+
+// EditAttemptSplits edits the split times of a single attempt.
+func (a *App) EditAttemptSplits(attemptsID string, attemptID int, newSplits []int64) map[string]any {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	if !att.EditAttemptSplits(attemptID, newSplits) {
+		return nil
+	}
+
+	if err := a.store.SaveAttempts(att); err != nil {
+		fmt.Printf("Warning: could not save attempts: %v\n", err)
+
+		return nil
+	}
+
+	if a.attempts != nil && a.attempts.ID == attemptsID {
+		a.attempts = att
+	}
+
+	return a.buildAttemptsData(att)
+}
+
+// EditAttemptMetadata edits the notes, tags, video link and custom fields of a single attempt.
+func (a *App) EditAttemptMetadata(attemptsID string, attemptID int, meta split.AttemptMetadata) map[string]any {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	if !att.EditAttemptMetadata(attemptID, meta) {
+		return nil
+	}
+
+	if err := a.store.SaveAttempts(att); err != nil {
+		fmt.Printf("Warning: could not save attempts: %v\n", err)
+
+		return nil
+	}
+
+	if a.attempts != nil && a.attempts.ID == attemptsID {
+		a.attempts = att
+	}
+
+	return a.buildAttemptsData(att)
+}
+
+// AddManualAttempt records a hand-entered attempt. startedAt is a Unix timestamp in seconds.
+func (a *App) AddManualAttempt(attemptsID string, splitTimesMS []int64, startedAt int64) map[string]any {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	if !att.AddManualAttempt(splitTimesMS, time.Unix(startedAt, 0)) {
+		return nil
+	}
+
+	if err := a.store.SaveAttempts(att); err != nil {
+		fmt.Printf("Warning: could not save attempts: %v\n", err)
+
+		return nil
+	}
+
+	if a.attempts != nil && a.attempts.ID == attemptsID {
+		a.attempts = att
+	}
+
+	return a.buildAttemptsData(att)
+}
+
+// HasAttemptGaps reports whether an attempt has skipped segments that can be interpolated.
+func (a *App) HasAttemptGaps(attemptsID string, attemptID int) bool {
+	if a.store == nil {
+		return false
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		return false
+	}
+
+	return att.HasEstimableGaps(attemptID)
+}
+
+// FillAttemptGaps interpolates skipped segments in an attempt and returns updated data.
+func (a *App) FillAttemptGaps(attemptsID string, attemptID int) map[string]any {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	if !att.EstimateGaps(attemptID) {
+		return nil
+	}
+
+	if err := a.store.SaveAttempts(att); err != nil {
+		fmt.Printf("Warning: could not save attempts: %v\n", err)
+
+		return nil
+	}
+
+	if a.attempts != nil && a.attempts.ID == attemptsID {
+		a.attempts = att
+	}
+
+	return a.buildAttemptsData(att)
+}
+
+// GetAttemptHistory returns the full attempt history for an attempts entry.
+func (a *App) GetAttemptHistory(attemptsID string) []split.Attempt {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	return att.History
+}
+
+// GetAttemptHistoryByTag returns the attempts in an attempts entry that carry the given tag.
+func (a *App) GetAttemptHistoryByTag(attemptsID, tag string) []split.Attempt {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
+	}
+
+	return att.HistoryWithTag(tag)
+}
+
+// FilterAttemptHistory returns the attempts in an attempts entry that match the filter.
+func (a *App) FilterAttemptHistory(attemptsID string, filter split.HistoryFilter) []split.Attempt {
+	if a.store == nil {
+		return nil
+	}
+
+	att, err := a.store.LoadAttempts(attemptsID)
+	if err != nil {
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
+
+		return nil
 	}
 
-	return a.getAttemptsData()
+	return att.FilterHistory(filter)
 }
 
-// LoadAttempts loads attempts by ID and sets them as active.
-func (a *App) LoadAttempts(id string) map[string]any {
+// GetAttemptTags returns every tag used in an attempts entry.
+func (a *App) GetAttemptTags(attemptsID string) []string {
 	if a.store == nil {
 		return nil
 	}
 
-	att, err := a.store.LoadAttempts(id)
+	att, err := a.store.LoadAttempts(attemptsID)
 	if err != nil {
 		fmt.Printf("Warning: could not load attempts: %v\n", err)
 
 		return nil
 	}
 
-	a.attempts = att
-	a.engine.SetSegments(att.SegmentNames())
-
-	return a.getAttemptsData()
+	return att.Tags()
 }
 
-// ListAttemptsForTemplate returns all attempts for a given template.
-func (a *App) ListAttemptsForTemplate(templateID string) []persist.AttemptsSummary {
+// GetSegmentTrends returns per-segment trend analysis over the most recent window attempts.
+// A window of 0 or less fits the full history.
+func (a *App) GetSegmentTrends(attemptsID string, window int) []split.SegmentTrend {
 	if a.store == nil {
 		return nil
 	}
 
-	summaries, err := a.store.ListAttemptsForTemplate(templateID)
+	att, err := a.store.LoadAttempts(attemptsID)
 	if err != nil {
-		fmt.Printf("Warning: could not list attempts: %v\n", err)
+		fmt.Printf("Warning: could not load attempts: %v\n", err)
 
 		return nil
 	}
 
-	return summaries
+	return att.SegmentTrends(window)
 }
 
-// DeleteAttempts deletes an attempts entry by ID.
-func (a *App) DeleteAttempts(id string) bool {
-	if a.store == nil {
-		return false
+// StartSession explicitly starts a practice session on the active attempts entry,
+// ending any session already open. Returns the new session ID, or 0 if no entry is active.
+func (a *App) StartSession() int {
+	if a.attempts == nil {
+		return 0
 	}
 
-	if err := a.store.DeleteAttempts(id); err != nil {
-		fmt.Printf("Warning: could not delete attempts: %v\n", err)
+	id := a.attempts.StartSession(time.Now())
+	a.saveActiveAttempts()
+
+	return id
+}
 
+// EndSession ends the open practice session on the active attempts entry.
+func (a *App) EndSession() bool {
+	if a.attempts == nil || !a.attempts.EndSession(time.Now()) {
 		return false
 	}
 
-	if a.attempts != nil && a.attempts.ID == id {
-		a.attempts = nil
-	}
+	a.saveActiveAttempts()
 
 	return true
 }
 
-// UpdateCategoryName renames a category on an attempts entry.
-func (a *App) UpdateCategoryName(attemptsID, newName string) map[string]any {
+// GetSessions returns per-session statistics for an attempts entry.
+func (a *App) GetSessions(attemptsID string) []split.SessionStats {
 	if a.store == nil {
 		return nil
 	}
@@ -444,159 +2316,211 @@ func (a *App) UpdateCategoryName(attemptsID, newName string) map[string]any {
 		return nil
 	}
 
-	att.CategoryName = newName
-	att.UpdatedAt = time.Now()
-
-	if err := a.store.SaveAttempts(att); err != nil {
-		fmt.Printf("Warning: could not save attempts: %v\n", err)
+	return att.SessionStats(time.Duration(a.settings.SessionIdleGapMinutes) * time.Minute)
+}
 
-		return nil
+func (a *App) saveActiveAttempts() {
+	if a.store == nil {
+		return
 	}
 
-	if a.attempts != nil && a.attempts.ID == attemptsID {
-		a.attempts = att
+	if err := a.store.SaveAttempts(a.attempts); err != nil {
+		fmt.Printf("Warning: could not save attempts: %v\n", err)
 	}
-
-	return a.buildAttemptsData(att)
 }
 
-// DeleteSingleAttempt removes a single attempt from an attempts entry.
-func (a *App) DeleteSingleAttempt(attemptsID string, attemptID int) map[string]any {
+// GetPlayTime returns play time per category and template, and across all saved data.
+func (a *App) GetPlayTime() *persist.PlayTimeSummary {
 	if a.store == nil {
 		return nil
 	}
 
-	att, err := a.store.LoadAttempts(attemptsID)
+	summary, err := a.store.PlayTimeSummary(a.settings.PlayTimeIncludesPause)
 	if err != nil {
-		fmt.Printf("Warning: could not load attempts: %v\n", err)
+		fmt.Printf("Warning: could not compute play time: %v\n", err)
 
 		return nil
 	}
 
-	if !att.DeleteAttempt(attemptID) {
-		return nil
-	}
+	return &summary
+}
 
-	if err := a.store.SaveAttempts(att); err != nil {
-		fmt.Printf("Warning: could not save attempts: %v\n", err)
+// GetCurrentTemplate returns the currently selected template data.
+func (a *App) GetCurrentTemplate() map[string]any {
+	return a.getTemplateData()
+}
+
+// GetCurrentAttempts returns the currently active attempts data.
+func (a *App) GetCurrentAttempts() map[string]any {
+	return a.getAttemptsData()
+}
 
+// GetDeltas returns the current deltas compared to PB.
+func (a *App) GetDeltas() []split.Delta {
+	if a.attempts == nil {
 		return nil
 	}
 
-	if a.attempts != nil && a.attempts.ID == attemptsID {
-		a.attempts = att
-	}
+	return a.runDeltas(split.ComputeSplitDeltas(a.variableView(a.attempts), a.engine.SplitTimesMS(), a.settings.Comparison))
+}
 
-	return a.buildAttemptsData(att)
+// runDeltas drops the deltas of segments a partial start preloaded rather than ran.
+func (a *App) runDeltas(deltas []split.Delta) []split.Delta {
+	return slices.DeleteFunc(deltas, func(d split.Delta) bool {
+		return d.SegmentIndex < a.runStartSegment
+	})
 }
 
-// EditAttemptSplits edits the split times of a single attempt.
-func (a *App) EditAttemptSplits(attemptsID string, attemptID int, newSplits []int64) map[string]any {
-	if a.store == nil {
+// GetHitDeltas returns the current hit deltas, or nil if the category is timed.
+func (a *App) GetHitDeltas() []split.HitDelta {
+	if a.attempts == nil || !a.attempts.HitCounting {
 		return nil
 	}
 
-	att, err := a.store.LoadAttempts(attemptsID)
-	if err != nil {
-		fmt.Printf("Warning: could not load attempts: %v\n", err)
+	return a.hitDeltas(a.variableView(a.attempts), len(a.engine.SplitTimesMS()))
+}
 
+// GetGhostDeltas returns the current deltas against the active ghost, or nil if
+// no ghost is being raced.
+func (a *App) GetGhostDeltas() []split.Delta {
+	if a.attempts == nil || a.attempts.ActiveGhost == "" {
 		return nil
 	}
 
-	if !att.EditAttemptSplits(attemptID, newSplits) {
-		return nil
-	}
+	comparison := split.GhostComparison(a.attempts.ActiveGhost)
 
-	if err := a.store.SaveAttempts(att); err != nil {
-		fmt.Printf("Warning: could not save attempts: %v\n", err)
+	return a.runDeltas(split.ComputeSplitDeltas(a.variableView(a.attempts), a.engine.SplitTimesMS(), comparison))
+}
 
+// GetGroupDeltas returns the current deltas for finished subsplit groups.
+func (a *App) GetGroupDeltas() []split.GroupDelta {
+	if a.attempts == nil {
 		return nil
 	}
 
-	if a.attempts != nil && a.attempts.ID == attemptsID {
-		a.attempts = att
+	return a.groupDeltas(a.variableView(a.attempts), a.engine.SplitTimesMS())
+}
+
+// groupDeltas computes the deltas of finished groups, marking them collapsed
+// when the settings ask for finished groups to be shown as a single row.
+func (a *App) groupDeltas(view *split.Attempts, splits []int64) []split.GroupDelta {
+	deltas := split.ComputeGroupDeltas(view, splits, a.settings.Comparison)
+	for i := range deltas {
+		deltas[i].Collapsed = a.settings.CollapseFinishedGroups
 	}
 
-	return a.buildAttemptsData(att)
+	return deltas
 }
 
-// HasAttemptGaps reports whether an attempt has skipped segments that can be interpolated.
-func (a *App) HasAttemptGaps(attemptsID string, attemptID int) bool {
+// ListProfiles returns the runner profiles sharing this install.
+func (a *App) ListProfiles() []persist.Profile {
 	if a.store == nil {
-		return false
+		return nil
 	}
 
-	att, err := a.store.LoadAttempts(attemptsID)
+	profiles, err := a.store.ListProfiles()
 	if err != nil {
-		return false
+		fmt.Printf("Warning: could not list profiles: %v\n", err)
+
+		return nil
 	}
 
-	return att.HasEstimableGaps(attemptID)
+	return profiles
 }
 
-// FillAttemptGaps interpolates skipped segments in an attempt and returns updated data.
-func (a *App) FillAttemptGaps(attemptsID string, attemptID int) map[string]any {
+// GetActiveProfile returns the ID of the active runner profile.
+func (a *App) GetActiveProfile() string {
 	if a.store == nil {
-		return nil
+		return ""
 	}
 
-	att, err := a.store.LoadAttempts(attemptsID)
-	if err != nil {
-		fmt.Printf("Warning: could not load attempts: %v\n", err)
+	return a.store.ActiveProfile()
+}
 
+// CreateProfile adds a runner profile with its own settings and history.
+func (a *App) CreateProfile(name string) *persist.Profile {
+	if a.store == nil {
 		return nil
 	}
 
-	if !att.EstimateGaps(attemptID) {
+	p, err := a.store.CreateProfile(uuid.NewString(), name)
+	if err != nil {
+		fmt.Printf("Warning: could not create profile: %v\n", err)
+
 		return nil
 	}
 
-	if err := a.store.SaveAttempts(att); err != nil {
-		fmt.Printf("Warning: could not save attempts: %v\n", err)
+	return p
+}
 
-		return nil
+// RenameProfile changes a runner profile's name.
+func (a *App) RenameProfile(id, name string) bool {
+	if a.store == nil {
+		return false
 	}
 
-	if a.attempts != nil && a.attempts.ID == attemptsID {
-		a.attempts = att
+	if err := a.store.RenameProfile(id, name); err != nil {
+		fmt.Printf("Warning: could not rename profile: %v\n", err)
+
+		return false
 	}
 
-	return a.buildAttemptsData(att)
+	return true
 }
 
-// GetAttemptHistory returns the full attempt history for an attempts entry.
-func (a *App) GetAttemptHistory(attemptsID string) []split.Attempt {
-	if a.store == nil {
-		return nil
+// SwitchProfile makes another runner's settings, categories and history active
+// without restarting. Only valid while the timer is idle; the loaded template
+// and category are closed.
+func (a *App) SwitchProfile(id string) bool {
+	a.runMu.Lock()
+	defer a.runMu.Unlock()
+
+	if a.store == nil || a.engine.CurrentState() != timer.Idle || a.cd.Active() {
+		return false
 	}
 
-	att, err := a.store.LoadAttempts(attemptsID)
-	if err != nil {
-		fmt.Printf("Warning: could not load attempts: %v\n", err)
+	if err := a.store.SwitchProfile(id); err != nil {
+		fmt.Printf("Warning: could not switch profile: %v\n", err)
 
-		return nil
+		return false
 	}
 
-	return att.History
-}
+	settings, err := a.store.LoadSettings()
+	if err != nil {
+		fmt.Printf("Warning: could not load settings: %v\n", err)
+	}
 
-// GetCurrentTemplate returns the currently selected template data.
-func (a *App) GetCurrentTemplate() map[string]any {
-	return a.getTemplateData()
-}
+	a.settings = settings
+	a.tmpl = nil
+	a.attempts = nil
+	a.segmentMode = nil
+	a.segmentUndo = nil
+	a.engine.SetSegments(nil)
 
-// GetCurrentAttempts returns the currently active attempts data.
-func (a *App) GetCurrentAttempts() map[string]any {
-	return a.getAttemptsData()
+	// The timer is idle, so the lock follows the new profile's auto-lock setting.
+	a.setInputLock(settings.AutoLockWhenIdle)
+
+	runtime.WindowSetAlwaysOnTop(a.ctx, settings.AlwaysOnTop)
+	runtime.EventsEmit(a.ctx, "settings:updated", settings)
+	runtime.EventsEmit(a.ctx, "profile:changed", id)
+
+	return true
 }
 
-// GetDeltas returns the current deltas compared to PB.
-func (a *App) GetDeltas() []split.Delta {
-	if a.attempts == nil {
-		return nil
+// DeleteProfile removes a runner profile with all of its history. The default
+// and the active profile cannot be deleted.
+func (a *App) DeleteProfile(id string) bool {
+	if a.store == nil {
+		return false
+	}
+
+	if err := a.store.DeleteProfile(id); err != nil {
+		fmt.Printf("Warning: could not delete profile: %v\n", err)
+
+		return false
 	}
 
-	return split.ComputeSplitDeltas(a.attempts, a.engine.SplitTimesMS(), a.settings.Comparison)
+	return true
 }
 
 // GetSettings returns the current application settings.
@@ -615,10 +2539,15 @@ func (a *App) UpdateSettings(settings persist.Settings) bool {
 	}
 
 	prevComparison := a.settings.Comparison
+	prevAutoLock := a.settings.AutoLockWhenIdle
 	a.settings = settings
 	runtime.WindowSetAlwaysOnTop(a.ctx, settings.AlwaysOnTop)
 	runtime.EventsEmit(a.ctx, "settings:updated", settings)
 
+	if settings.AutoLockWhenIdle && !prevAutoLock && a.engine != nil && a.engine.CurrentState() != timer.Running {
+		a.setInputLock(true)
+	}
+
 	// Re-emit attempts data when comparison changes (split rows show comparison splits).
 	if settings.Comparison != prevComparison && a.attempts != nil {
 		runtime.EventsEmit(a.ctx, "attempts:updated", a.getAttemptsData())
@@ -627,8 +2556,10 @@ func (a *App) UpdateSettings(settings persist.Settings) bool {
 	return true
 }
 
-func (a *App) saveSuspendedRun() {
-	if a.store == nil || a.tmpl == nil || a.attempts == nil {
+// saveSuspendedRun checkpoints the in-progress run. Explicit is true when the
+// run is deliberately suspended (or the app shuts down) rather than checkpointed mid-run.
+func (a *App) saveSuspendedRun(explicit bool) {
+	if a.store == nil || a.tmpl == nil || a.attempts == nil || a.segmentMode != nil {
 		return
 	}
 
@@ -645,6 +2576,10 @@ func (a *App) saveSuspendedRun() {
 		SplitTimesMS:   a.engine.SplitTimesMS(),
 		SegmentTimesMS: a.engine.SegmentTimesMS(),
 		SuspendedAt:    time.Now().Unix(),
+		StartedAt:      a.runStartedAt.Unix(),
+		StartSegment:   a.runStartSegment,
+		Hits:           a.runHits(),
+		Explicit:       explicit,
 	}
 
 	if err := a.store.SaveSuspendedRun(run); err != nil {
@@ -702,12 +2637,16 @@ func (a *App) CheckSuspendedRun() map[string]any {
 		"currentSegment": run.CurrentSegment,
 		"totalSegments":  len(tmpl.SegmentNames),
 		"suspendedAt":    run.SuspendedAt,
+		"crashed":        !run.Explicit,
 	}
 }
 
 // ResumeSuspendedRun loads a suspended run, restores the engine, and returns template + attempts data.
 func (a *App) ResumeSuspendedRun() map[string]any {
-	if a.store == nil {
+	a.runMu.Lock()
+	defer a.runMu.Unlock()
+
+	if a.IsInputLocked() || a.store == nil {
 		return nil
 	}
 
@@ -728,8 +2667,12 @@ func (a *App) ResumeSuspendedRun() map[string]any {
 
 	a.tmpl = tmpl
 	a.attempts = att
-	a.engine.SetSegments(att.SegmentNames())
+	a.runStartedAt = suspendedRunStart(run)
+	a.runStartSegment = run.StartSegment
+	a.activateSegments(att)
 	a.engine.Restore(run.ElapsedMS, run.CurrentSegment, run.SplitTimesMS, run.SegmentTimesMS)
+	a.engine.RestoreHits(run.Hits)
+	a.engine.RestoreStartSegment(run.StartSegment)
 	a.emitDeltas()
 
 	return map[string]any{
@@ -741,30 +2684,133 @@ func (a *App) ResumeSuspendedRun() map[string]any {
 // SuspendRun explicitly suspends the current run and resets the engine.
 // Only valid from Running or Paused state.
 func (a *App) SuspendRun() {
+	a.runMu.Lock()
+	defer a.runMu.Unlock()
+
+	if a.IsInputLocked() {
+		return
+	}
+
 	state := a.engine.CurrentState()
 	if state != timer.Running && state != timer.Paused {
 		return
 	}
 
-	a.saveSuspendedRun()
+	a.saveSuspendedRun(true)
 	a.engine.Reset()
 }
 
-// DiscardSuspendedRun deletes the suspended run file.
+// DiscardSuspendedRun records the suspended run as an abandoned attempt and deletes the file.
+// Runs left behind by a crash are recorded as crash-recovered instead.
 func (a *App) DiscardSuspendedRun() {
+	if a.store == nil {
+		return
+	}
+
+	run, err := a.store.LoadSuspendedRun()
+	if err != nil {
+		fmt.Printf("Warning: could not load suspended run: %v\n", err)
+	}
+
+	if run != nil {
+		a.recordSuspendedRun(run)
+	}
+
 	a.deleteSuspendedRun()
 }
 
+func (a *App) recordSuspendedRun(run *persist.SuspendedRun) {
+	att, err := a.store.LoadAttempts(run.AttemptsID)
+	if err != nil {
+		return
+	}
+
+	reason := split.EndAbandonedSuspend
+	if !run.Explicit {
+		reason = split.EndCrashRecovered
+	}
+
+	end := split.AttemptEnd{
+		Reason:    reason,
+		Segment:   run.CurrentSegment,
+		StartedAt: suspendedRunStart(run),
+		EndedAt:   time.Unix(run.SuspendedAt, 0),
+		ElapsedMS: run.ElapsedMS,
+
+		StartSegment: run.StartSegment,
+		ExcludeGolds: run.StartSegment > 0 && !a.settings.PartialStartGolds,
+		Hits:         run.Hits,
+	}
+
+	att.RecordAttempt(run.SplitTimesMS, false, end)
+
+	if err := a.store.SaveAttempts(att); err != nil {
+		fmt.Printf("Warning: could not save attempts: %v\n", err)
+
+		return
+	}
+
+	a.creditMarathonLegs(att, run.SplitTimesMS, false, end)
+
+	if a.attempts != nil && a.attempts.ID == att.ID {
+		a.attempts = att
+		runtime.EventsEmit(a.ctx, "attempts:updated", a.getAttemptsData())
+	}
+}
+
+// suspendedRunStart returns the wall-clock start of a suspended run, estimating
+// it from the suspend time for files written before start times were saved.
+func suspendedRunStart(run *persist.SuspendedRun) time.Time {
+	if run.StartedAt != 0 {
+		return time.Unix(run.StartedAt, 0)
+	}
+
+	return time.Unix(run.SuspendedAt, 0).Add(-time.Duration(run.ElapsedMS) * time.Millisecond)
+}
+
 func (a *App) getTemplateData() map[string]any {
 	if a.tmpl == nil {
 		return nil
 	}
 
+	return templateData(a.tmpl)
+}
+
+func templateData(tmpl *split.Template) map[string]any {
 	return map[string]any{
-		"id":           a.tmpl.ID,
-		"name":         a.tmpl.Name,
-		"segmentNames": a.tmpl.SegmentNames,
+		"id":            tmpl.ID,
+		"name":          tmpl.Name,
+		"segmentNames":  tmpl.SegmentNames,
+		"segmentGroups": tmpl.SegmentGroups,
+		"segmentNotes":  tmpl.SegmentNotes,
+		"segmentIcons":  assetURLs(tmpl.SegmentIcons),
+		"coverImage":    assetURL(tmpl.CoverImage),
+		"variables":     tmpl.Variables,
+		"game":          tmpl.Game,
+		"legs":          tmpl.Legs,
+	}
+}
+
+// assetURL returns the URL the frontend loads an asset from, or "" for no asset.
+func assetURL(id string) string {
+	if id == "" {
+		return ""
+	}
+
+	return persist.AssetURLPrefix + id
+}
+
+func assetURLs(ids []string) []string {
+	if ids == nil {
+		return nil
+	}
+
+	urls := make([]string, len(ids))
+	for i, id := range ids {
+		urls[i] = assetURL(id)
 	}
+
+	return urls
 }
 
 func (a *App) getAttemptsData() map[string]any {
@@ -776,9 +2822,17 @@ func (a *App) getAttemptsData() map[string]any {
 }
 
 func (a *App) buildAttemptsData(att *split.Attempts) map[string]any {
-	pbSplits := att.PersonalBestSplits()
-	bestSegs := att.BestSegments()
-	compSplits := split.ComparisonSplits(att, a.settings.Comparison)
+	view := a.variableView(att)
+	pbSplits := view.PersonalBestSplits()
+	bestSegs := view.BestSegments()
+	compSplits := split.ComparisonSplits(view, a.settings.Comparison)
+	pbHits := view.PersonalBestHits()
+	bestHits := view.BestSegmentHits()
+
+	var ghostSplits []int64
+	if att.ActiveGhost != "" {
+		ghostSplits = split.ComparisonSplits(view, split.GhostComparison(att.ActiveGhost))
+	}
 
 	segments := make([]map[string]any, len(att.Segments))
 	for i, s := range att.Segments {
@@ -799,18 +2853,88 @@ func (a *App) buildAttemptsData(att *split.Attempts) map[string]any {
 
 		segments[i] = map[string]any{
 			"name":              s.Name,
+			"group":             s.Group,
+			"notes":             s.Notes,
 			"personalBestMs":    pb,
 			"bestSegmentMs":     bs,
 			"comparisonSplitMs": cs,
 		}
+
+		if i < len(ghostSplits) {
+			segments[i]["ghostSplitMs"] = ghostSplits[i]
+		}
+
+		if att.HitCounting {
+			segments[i]["bestSegmentHits"] = bestHits[i]
+			if pbHits != nil {
+				segments[i]["personalBestHits"] = pbHits[i]
+			}
+		}
 	}
 
 	return map[string]any{
-		"id":           att.ID,
-		"templateId":   att.TemplateID,
-		"name":         att.Name,
-		"categoryName": att.CategoryName,
-		"segments":     segments,
-		"attemptCount": att.AttemptCount,
+		"id":             att.ID,
+		"templateId":     att.TemplateID,
+		"name":           att.Name,
+		"categoryName":   att.CategoryName,
+		"segments":       segments,
+		"groups":         view.GroupSummaries(),
+		"attemptCount":   att.AttemptCount,
+		"playTimeMs":     att.PlayTime(a.settings.PlayTimeIncludesPause).TotalMS,
+		"variableValues": att.VariableValues,
+		"hitCounting":    att.HitCounting,
+		"ghosts":         att.Ghosts,
+		"activeGhost":    att.ActiveGhost,
+		"resetPolicy":    att.ResetPolicyOrDefault(),
+	}
+}
+
+// creditMarathonLegs records a marathon attempt on the entries of the games it
+// chains. Does nothing if att's template is not a marathon.
+func (a *App) creditMarathonLegs(att *split.Attempts, splits []int64, completed bool, end split.AttemptEnd) {
+	tmpl := a.tmpl
+	if tmpl == nil || tmpl.ID != att.TemplateID {
+		var err error
+		if tmpl, err = a.store.LoadTemplate(att.TemplateID); err != nil {
+			return
+		}
+	}
+
+	if !tmpl.IsMarathon() {
+		return
+	}
+
+	total := 0
+	for _, leg := range tmpl.Legs {
+		total += leg.SegmentCount
+	}
+
+	if total != len(att.Segments) {
+		fmt.Printf("Warning: marathon segments no longer match its games; not crediting legs\n")
+
+		return
+	}
+
+	if _, err := a.store.CreditMarathonLegs(tmpl.Legs, splits, completed, end); err != nil {
+		fmt.Printf("Warning: could not credit marathon legs: %v\n", err)
+	}
+}
+
+// variableView narrows att to the attempts sharing its current variable values,
+// so PB, golds and comparisons reflect the sub-category being run.
+func (a *App) variableView(att *split.Attempts) *split.Attempts {
+	if len(att.VariableValues) == 0 {
+		return att
 	}
+
+	var vars []split.Variable
+	if a.tmpl != nil && a.tmpl.ID == att.TemplateID {
+		vars = a.tmpl.Variables
+	} else if a.store != nil {
+		if tmpl, err := a.store.LoadTemplate(att.TemplateID); err == nil {
+			vars = tmpl.Variables
+		}
+	}
+
+	return att.ForVariables(vars, att.VariableValues)
 }
@@ -11,6 +11,10 @@ const defaultSettings: Settings = {
     reset: 'KeyR',
     undoSplit: 'Backspace',
     skipSplit: 'KeyS',
+    hit: 'KeyH',
+    undoHit: 'KeyJ',
+    countdown: 'KeyC',
+    toggleLock: 'KeyL',
   },
   comparison: 'personal_best',
   colors: {
@@ -81,6 +81,10 @@ export interface HotkeyBindings {
   reset: string;
   undoSplit: string;
   skipSplit: string;
+  hit: string;
+  undoHit: string;
+  countdown: string;
+  toggleLock: string;
 }
 
 export interface ColorSettings {
@@ -39,6 +39,10 @@ export function GetSettings():Promise<persist.Settings>;
 
 export function HasAttemptGaps(arg1:string,arg2:number):Promise<boolean>;
 
+export function Hit():Promise<void>;
+
+export function IsInputLocked():Promise<boolean>;
+
 export function ListAttemptsForTemplate(arg1:string):Promise<Array<persist.AttemptsSummary>>;
 
 export function ListTemplates():Promise<Array<persist.TemplateSummary>>;
@@ -57,10 +61,20 @@ export function StartSplit():Promise<void>;
 
 export function SuspendRun():Promise<void>;
 
+export function TemplateProfiles(arg1:string):Promise<Array<string>>;
+
+export function ToggleCountdown():Promise<boolean>;
+
+export function ToggleInputLock():Promise<void>;
+
 export function TogglePause():Promise<void>;
 
+export function UndoHit():Promise<void>;
+
 export function UndoSplit():Promise<void>;
 
+export function UnlockInput():Promise<void>;
+
 export function UpdateCategoryName(arg1:string,arg2:string):Promise<Record<string, any>>;
 
 export function UpdateSettings(arg1:persist.Settings):Promise<boolean>;
@@ -74,6 +74,14 @@ export function HasAttemptGaps(arg1, arg2) {
   return window['go']['main']['App']['HasAttemptGaps'](arg1, arg2);
 }
 
+export function Hit() {
+  return window['go']['main']['App']['Hit']();
+}
+
+export function IsInputLocked() {
+  return window['go']['main']['App']['IsInputLocked']();
+}
+
 export function ListAttemptsForTemplate(arg1) {
   return window['go']['main']['App']['ListAttemptsForTemplate'](arg1);
 }
@@ -110,14 +118,34 @@ export function SuspendRun() {
   return window['go']['main']['App']['SuspendRun']();
 }
 
+export function TemplateProfiles(arg1) {
+  return window['go']['main']['App']['TemplateProfiles'](arg1);
+}
+
+export function ToggleCountdown() {
+  return window['go']['main']['App']['ToggleCountdown']();
+}
+
+export function ToggleInputLock() {
+  return window['go']['main']['App']['ToggleInputLock']();
+}
+
 export function TogglePause() {
   return window['go']['main']['App']['TogglePause']();
 }
 
+export function UndoHit() {
+  return window['go']['main']['App']['UndoHit']();
+}
+
 export function UndoSplit() {
   return window['go']['main']['App']['UndoSplit']();
 }
 
+export function UnlockInput() {
+  return window['go']['main']['App']['UnlockInput']();
+}
+
 export function UpdateCategoryName(arg1, arg2) {
   return window['go']['main']['App']['UpdateCategoryName'](arg1, arg2);
 }
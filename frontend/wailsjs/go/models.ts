@@ -48,6 +48,10 @@ export namespace persist {
 	    reset: string;
 	    undoSplit: string;
 	    skipSplit: string;
+	    hit: string;
+	    undoHit: string;
+	    countdown: string;
+	    toggleLock: string;
 	
 	    static createFrom(source: any = {}) {
 	        return new HotkeyBindings(source);
@@ -60,6 +64,10 @@ export namespace persist {
 	        this.reset = source["reset"];
 	        this.undoSplit = source["undoSplit"];
 	        this.skipSplit = source["skipSplit"];
+	        this.hit = source["hit"];
+	        this.undoHit = source["undoHit"];
+	        this.countdown = source["countdown"];
+	        this.toggleLock = source["toggleLock"];
 	    }
 	}
 	export class Settings {
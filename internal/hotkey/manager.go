@@ -1,5 +1,7 @@
 package hotkey
 
+import "sync"
+
 // Action represents a hotkey action.
 type Action int
 
@@ -9,6 +11,10 @@ const (
 	ActionReset                    // Reset the timer.
 	ActionUndoSplit                // Undo the last split.
 	ActionSkipSplit                // Skip the current segment.
+	ActionHit                      // Count a hit in the current segment (no-hit runs).
+	ActionUndoHit                  // Remove the last hit from the current segment.
+	ActionCountdown                // Start the race countdown, or cancel it if running.
+	ActionToggleLock               // Lock or unlock input; the only action allowed while locked.
 )
 
 func (a Action) String() string {
@@ -23,6 +29,14 @@ func (a Action) String() string {
 		return "undo_split"
 	case ActionSkipSplit:
 		return "skip_split"
+	case ActionHit:
+		return "hit"
+	case ActionUndoHit:
+		return "undo_hit"
+	case ActionCountdown:
+		return "countdown"
+	case ActionToggleLock:
+		return "toggle_lock"
 	default:
 		return "unknown"
 	}
@@ -36,9 +50,14 @@ type Handler func(Action)
 // and platform-specific setup. For the MVP, hotkeys are handled via the
 // frontend (keyboard events) and the Wails-bound methods. True global
 // hotkeys (when window is unfocused) can be added as an enhancement.
+//
+// While locked, every action except ActionToggleLock is dropped. Any input
+// source, including external control, should go through Dispatch or check Locked.
 type Manager struct {
+	mu      sync.Mutex
 	handler Handler
 	enabled bool
+	locked  bool
 }
 
 // NewManager creates a hotkey manager with the given handler.
@@ -57,12 +76,43 @@ func (m *Manager) Start() error {
 
 // Stop stops listening for hotkeys.
 func (m *Manager) Stop() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.enabled = false
 }
 
+// SetLocked locks or unlocks input. Returns false if the state did not change.
+func (m *Manager) SetLocked(locked bool) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.locked == locked {
+		return false
+	}
+
+	m.locked = locked
+
+	return true
+}
+
+// Locked reports whether input is locked.
+func (m *Manager) Locked() bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.locked
+}
+
 // Dispatch manually triggers a hotkey action (used by frontend bridge).
+// Actions other than ActionToggleLock are dropped while locked.
 func (m *Manager) Dispatch(action Action) {
-	if m.enabled && m.handler != nil {
+	m.mu.Lock()
+	ok := m.enabled && m.handler != nil && (!m.locked || action == ActionToggleLock)
+	m.mu.Unlock()
+
+	// The handler runs unlocked so it can change the lock state.
+	if ok {
 		m.handler(action)
 	}
 }
@@ -0,0 +1,140 @@
+package persist
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// AssetURLPrefix is the path under which the frontend loads stored assets.
+const AssetURLPrefix = "/store-assets/"
+
+// imageExtensions lists the file types accepted as assets. SVG is left out:
+// it can carry script, and assets are served from the app's own origin.
+var imageExtensions = map[string]bool{
+	".png":  true,
+	".jpg":  true,
+	".jpeg": true,
+	".gif":  true,
+	".webp": true,
+}
+
+// ErrInvalidAsset is returned for asset IDs or file types the store does not accept.
+var ErrInvalidAsset = errors.New("invalid asset")
+
+// SaveAsset stores image data and returns its asset ID: the SHA-256 of the
+// content plus the file extension. Saving the same image twice yields the same ID.
+func (s *Store) SaveAsset(data []byte, ext string) (string, error) {
+	ext = strings.ToLower(ext)
+	if !imageExtensions[ext] {
+		return "", fmt.Errorf("%w: unsupported file type %q", ErrInvalidAsset, ext)
+	}
+
+	sum := sha256.Sum256(data)
+	id := hex.EncodeToString(sum[:]) + ext
+	path := s.assetPath(id)
+
+	if _, err := os.Stat(path); err == nil {
+		return id, nil
+	}
+
+	tmpPath := path + ".tmp"
+
+	if err := os.WriteFile(tmpPath, data, 0o640); err != nil {
+		return "", fmt.Errorf("writing temp file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+
+		return "", fmt.Errorf("renaming temp file: %w", err)
+	}
+
+	return id, nil
+}
+
+// LoadAsset reads a stored asset by ID.
+func (s *Store) LoadAsset(id string) ([]byte, error) {
+	if !validAssetID(id) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidAsset, id)
+	}
+
+	data, err := os.ReadFile(s.assetPath(id))
+	if err != nil {
+		return nil, fmt.Errorf("reading asset file: %w", err)
+	}
+
+	return data, nil
+}
+
+// PruneAssets deletes every stored asset that no template refers to.
+func (s *Store) PruneAssets() error {
+	entries, err := os.ReadDir(filepath.Join(s.baseDir, "assets"))
+	if err != nil {
+		return fmt.Errorf("reading assets directory: %w", err)
+	}
+
+	used, err := s.usedAssets()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || used[entry.Name()] || !validAssetID(entry.Name()) {
+			continue
+		}
+
+		if err := os.Remove(s.assetPath(entry.Name())); err != nil {
+			return fmt.Errorf("deleting asset file: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Store) usedAssets() (map[string]bool, error) {
+	summaries, err := s.ListTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	used := make(map[string]bool)
+
+	for _, summary := range summaries {
+		tmpl, err := s.LoadTemplate(summary.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, id := range tmpl.AssetIDs() {
+			used[id] = true
+		}
+	}
+
+	return used, nil
+}
+
+// validAssetID reports whether id has the form SaveAsset produces.
+func validAssetID(id string) bool {
+	ext := filepath.Ext(id)
+	if !imageExtensions[ext] {
+		return false
+	}
+
+	hash := strings.TrimSuffix(id, ext)
+	if len(hash) != sha256.Size*2 {
+		return false
+	}
+
+	_, err := hex.DecodeString(hash)
+
+	return err == nil
+}
+
+func (s *Store) assetPath(id string) string {
+	return filepath.Join(s.baseDir, "assets", id)
+}
@@ -0,0 +1,89 @@
+package persist
+
+import (
+	"errors"
+	"testing"
+
+	"goldsplit/internal/split"
+)
+
+func TestSaveAssetIsContentAddressed(t *testing.T) {
+	store := tempStore(t)
+
+	id1, err := store.SaveAsset([]byte("png-data"), ".PNG")
+	if err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	id2, err := store.SaveAsset([]byte("png-data"), ".png")
+	if err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	if id1 != id2 {
+		t.Fatalf("expected identical content to share an ID, got %s and %s", id1, id2)
+	}
+
+	data, err := store.LoadAsset(id1)
+	if err != nil || string(data) != "png-data" {
+		t.Fatalf("unexpected asset data %q, err %v", data, err)
+	}
+}
+
+func TestSaveAssetRejectsUnknownType(t *testing.T) {
+	store := tempStore(t)
+
+	for _, ext := range []string{".exe", ".svg"} {
+		if _, err := store.SaveAsset([]byte("x"), ext); !errors.Is(err, ErrInvalidAsset) {
+			t.Fatalf("expected ErrInvalidAsset for %s, got %v", ext, err)
+		}
+	}
+}
+
+func TestLoadAssetRejectsPaths(t *testing.T) {
+	store := tempStore(t)
+
+	if _, err := store.LoadAsset("../settings.json"); !errors.Is(err, ErrInvalidAsset) {
+		t.Fatalf("expected ErrInvalidAsset, got %v", err)
+	}
+}
+
+func TestPruneAssetsAfterDeleteTemplate(t *testing.T) {
+	store := tempStore(t)
+
+	shared, _ := store.SaveAsset([]byte("shared"), ".png")
+	own, _ := store.SaveAsset([]byte("own"), ".png")
+
+	tmpl1 := split.NewTemplate("t-1", "Game 1", []string{"A", "B"})
+	tmpl1.CoverImage = shared
+	tmpl1.SetSegmentIcon(1, own)
+
+	tmpl2 := split.NewTemplate("t-2", "Game 2", []string{"A"})
+	tmpl2.SetSegmentIcon(0, shared)
+
+	for _, tmpl := range []*split.Template{tmpl1, tmpl2} {
+		if err := store.SaveTemplate(tmpl); err != nil {
+			t.Fatalf("save failed: %v", err)
+		}
+	}
+
+	if err := store.DeleteTemplate("t-1"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+
+	if _, err := store.LoadAsset(own); err != nil {
+		t.Fatalf("expected assets to be kept until pruned: %v", err)
+	}
+
+	if err := store.PruneAssets(); err != nil {
+		t.Fatalf("prune failed: %v", err)
+	}
+
+	if _, err := store.LoadAsset(own); err == nil {
+		t.Fatal("expected unshared asset to be removed")
+	}
+
+	if _, err := store.LoadAsset(shared); err != nil {
+		t.Fatalf("expected shared asset to be kept: %v", err)
+	}
+}
@@ -0,0 +1,123 @@
+package persist
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"goldsplit/internal/split"
+)
+
+// bundleVersion is the current export format version.
+const bundleVersion = 1
+
+// Bundle is a self-contained export of a template, its attempts and the images
+// they use. Asset data is keyed by asset ID.
+type Bundle struct {
+	Version  int               `json:"version"`
+	Template *split.Template   `json:"template"`
+	Attempts []*split.Attempts `json:"attempts"`
+	Assets   map[string][]byte `json:"assets,omitempty"`
+}
+
+// ExportBundle collects a template with all of its attempts and assets.
+func (s *Store) ExportBundle(templateID string) (*Bundle, error) {
+	tmpl, err := s.LoadTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	summaries, err := s.ListAttemptsForTemplate(templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Bundle{Version: bundleVersion, Template: tmpl}
+
+	for _, summary := range summaries {
+		att, err := s.LoadAttempts(summary.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		b.Attempts = append(b.Attempts, att)
+	}
+
+	for _, id := range tmpl.AssetIDs() {
+		data, err := s.LoadAsset(id)
+		if err != nil {
+			return nil, err
+		}
+
+		if b.Assets == nil {
+			b.Assets = make(map[string][]byte)
+		}
+
+		b.Assets[id] = data
+	}
+
+	return b, nil
+}
+
+// ImportBundle saves a bundle's template, attempts and assets under fresh IDs
+// from newID, so importing never overwrites existing data. Returns the new template.
+func (s *Store) ImportBundle(b *Bundle, newID func() string) (*split.Template, error) {
+	if b.Template == nil {
+		return nil, fmt.Errorf("bundle has no template")
+	}
+
+	if b.Version > bundleVersion {
+		return nil, fmt.Errorf("unsupported bundle version %d", b.Version)
+	}
+
+	// Asset IDs are content hashes, so storing the data must reproduce them.
+	for id, data := range b.Assets {
+		stored, err := s.SaveAsset(data, filepath.Ext(id))
+		if err != nil {
+			return nil, err
+		}
+
+		if stored != id {
+			return nil, fmt.Errorf("%w: content does not match %q", ErrInvalidAsset, id)
+		}
+	}
+
+	tmpl := *b.Template
+	tmpl.ID = newID()
+
+	for _, att := range b.Attempts {
+		imported := *att
+		imported.ID = newID()
+		imported.TemplateID = tmpl.ID
+
+		if err := s.SaveAttempts(&imported); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.SaveTemplate(&tmpl); err != nil {
+		return nil, err
+	}
+
+	return &tmpl, nil
+}
+
+// MarshalBundle encodes a bundle for writing to an export file.
+func MarshalBundle(b *Bundle) ([]byte, error) {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling bundle: %w", err)
+	}
+
+	return data, nil
+}
+
+// UnmarshalBundle decodes an export file.
+func UnmarshalBundle(data []byte) (*Bundle, error) {
+	var b Bundle
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("unmarshaling bundle: %w", err)
+	}
+
+	return &b, nil
+}
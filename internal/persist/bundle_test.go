@@ -0,0 +1,83 @@
+package persist
+
+import (
+	"fmt"
+	"testing"
+
+	"goldsplit/internal/split"
+)
+
+func TestBundleRoundTrip(t *testing.T) {
+	src := tempStore(t)
+
+	icon, _ := src.SaveAsset([]byte("icon"), ".png")
+
+	tmpl := split.NewTemplate("t-1", "Game", []string{"A", "B"})
+	tmpl.SetSegmentIcon(0, icon)
+
+	att := split.NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+	att.AddAttempt([]int64{1000, 2000}, true)
+
+	if err := src.SaveTemplate(tmpl); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	if err := src.SaveAttempts(att); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	bundle, err := src.ExportBundle("t-1")
+	if err != nil {
+		t.Fatalf("export failed: %v", err)
+	}
+
+	data, err := MarshalBundle(bundle)
+	if err != nil {
+		t.Fatalf("marshal failed: %v", err)
+	}
+
+	decoded, err := UnmarshalBundle(data)
+	if err != nil {
+		t.Fatalf("unmarshal failed: %v", err)
+	}
+
+	dst := tempStore(t)
+	n := 0
+
+	imported, err := dst.ImportBundle(decoded, func() string {
+		n++
+
+		return fmt.Sprintf("new-%d", n)
+	})
+	if err != nil {
+		t.Fatalf("import failed: %v", err)
+	}
+
+	if imported.ID == "t-1" || imported.SegmentIcons[0] != icon {
+		t.Fatalf("unexpected imported template: %+v", imported)
+	}
+
+	if data, err := dst.LoadAsset(icon); err != nil || string(data) != "icon" {
+		t.Fatalf("expected icon to be imported, got %q, err %v", data, err)
+	}
+
+	summaries, err := dst.ListAttemptsForTemplate(imported.ID)
+	if err != nil || len(summaries) != 1 || summaries[0].AttemptCount != 1 {
+		t.Fatalf("expected imported attempts, got %+v, err %v", summaries, err)
+	}
+}
+
+func TestImportBundleRejectsTamperedAsset(t *testing.T) {
+	store := tempStore(t)
+
+	id, _ := store.SaveAsset([]byte("icon"), ".png")
+	bundle := &Bundle{
+		Version:  bundleVersion,
+		Template: split.NewTemplate("t-1", "Game", []string{"A"}),
+		Assets:   map[string][]byte{id: []byte("other")},
+	}
+
+	if _, err := store.ImportBundle(bundle, func() string { return "x" }); err == nil {
+		t.Fatal("expected mismatched asset content to be rejected")
+	}
+}
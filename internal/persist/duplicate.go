@@ -0,0 +1,62 @@
+package persist
+
+import (
+	"fmt"
+
+	"goldsplit/internal/split"
+)
+
+// DuplicateAttempts saves a copy of an attempts entry under an ID from newID.
+// An empty templateID keeps the original's template link.
+func (s *Store) DuplicateAttempts(id, templateID, name, categoryName string, mode split.CopyMode, newID func() string) (*split.Attempts, error) {
+	att, err := s.LoadAttempts(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == "" {
+		templateID = att.TemplateID
+	}
+
+	c := att.Duplicate(newID(), templateID, name, categoryName, mode)
+	if c == nil {
+		return nil, fmt.Errorf("unknown copy mode %q", mode)
+	}
+
+	if err := s.SaveAttempts(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// DuplicateTemplate saves a copy of a template under a new name, with IDs from
+// newID. Unless categoryMode is empty, every attempts entry of the template is
+// copied as well, keeping the history categoryMode selects and linked to the copy.
+func (s *Store) DuplicateTemplate(id, name string, categoryMode split.CopyMode, newID func() string) (*split.Template, error) {
+	tmpl, err := s.LoadTemplate(id)
+	if err != nil {
+		return nil, err
+	}
+
+	c := tmpl.Duplicate(newID(), name)
+
+	if categoryMode != "" {
+		summaries, err := s.ListAttemptsForTemplate(id)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, summary := range summaries {
+			if _, err := s.DuplicateAttempts(summary.ID, c.ID, summary.Name, summary.CategoryName, categoryMode, newID); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := s.SaveTemplate(c); err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
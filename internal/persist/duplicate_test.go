@@ -0,0 +1,86 @@
+package persist
+
+import (
+	"fmt"
+	"testing"
+
+	"goldsplit/internal/split"
+)
+
+func sequentialIDs() func() string {
+	n := 0
+
+	return func() string {
+		n++
+
+		return fmt.Sprintf("new-%d", n)
+	}
+}
+
+func TestDuplicateTemplateWithCategories(t *testing.T) {
+	store := tempStore(t)
+
+	tmpl := split.NewTemplate("t-1", "Game", []string{"A", "B"})
+	att := split.NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+	att.AddAttempt([]int64{1000, 3000}, true)
+
+	if err := store.SaveTemplate(tmpl); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	if err := store.SaveAttempts(att); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	c, err := store.DuplicateTemplate("t-1", "Game (copy)", split.CopyPB, sequentialIDs())
+	if err != nil {
+		t.Fatalf("duplicate failed: %v", err)
+	}
+
+	if c.ID != "new-1" {
+		t.Fatalf("expected fresh template ID, got %s", c.ID)
+	}
+
+	summaries, err := store.ListAttemptsForTemplate(c.ID)
+	if err != nil || len(summaries) != 1 || summaries[0].ID != "new-2" || summaries[0].AttemptCount != 1 {
+		t.Fatalf("expected copied category linked to the copy, got %+v, err %v", summaries, err)
+	}
+
+	original, err := store.ListAttemptsForTemplate("t-1")
+	if err != nil || len(original) != 1 {
+		t.Fatalf("expected original category to remain, got %+v, err %v", original, err)
+	}
+}
+
+func TestDuplicateTemplateAlone(t *testing.T) {
+	store := tempStore(t)
+
+	if err := store.SaveTemplate(split.NewTemplate("t-1", "Game", []string{"A"})); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	if err := store.SaveAttempts(split.NewAttempts("a-1", "t-1", "", "Any%", []string{"A"})); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	c, err := store.DuplicateTemplate("t-1", "Copy", "", sequentialIDs())
+	if err != nil {
+		t.Fatalf("duplicate failed: %v", err)
+	}
+
+	if summaries, _ := store.ListAttemptsForTemplate(c.ID); len(summaries) != 0 {
+		t.Fatalf("expected no categories, got %+v", summaries)
+	}
+}
+
+func TestDuplicateAttemptsUnknownMode(t *testing.T) {
+	store := tempStore(t)
+
+	if err := store.SaveAttempts(split.NewAttempts("a-1", "t-1", "", "Any%", []string{"A"})); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	if _, err := store.DuplicateAttempts("a-1", "", "", "", "bogus", sequentialIDs()); err == nil {
+		t.Fatal("expected unknown mode to fail")
+	}
+}
@@ -0,0 +1,47 @@
+package persist
+
+import (
+	"errors"
+	"fmt"
+
+	"goldsplit/internal/split"
+)
+
+// CreditMarathonLegs records each leg's part of a marathon attempt on that leg's
+// attempts entry, so golds and PBs count towards the individual games. Legs whose
+// entry no longer has the segment count the marathon was built with are skipped,
+// as are legs that cannot be loaded or saved; the other legs are still credited.
+// Returns the IDs of the credited entries and the errors of any failed legs.
+func (s *Store) CreditMarathonLegs(legs []split.MarathonLeg, splits []int64, completed bool, end split.AttemptEnd) ([]string, error) {
+	var (
+		credited []string
+		errs     []error
+	)
+
+	for _, run := range split.MarathonLegRuns(legs, splits, completed, end) {
+		leg := legs[run.Leg]
+
+		att, err := s.LoadAttempts(leg.AttemptsID)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("crediting marathon leg %d: %w", run.Leg, err))
+
+			continue
+		}
+
+		if len(att.Segments) != leg.SegmentCount {
+			continue
+		}
+
+		att.RecordAttempt(run.SplitTimesMS, run.Completed, run.LegEnd(end))
+
+		if err := s.SaveAttempts(att); err != nil {
+			errs = append(errs, fmt.Errorf("crediting marathon leg %d: %w", run.Leg, err))
+
+			continue
+		}
+
+		credited = append(credited, att.ID)
+	}
+
+	return credited, errors.Join(errs...)
+}
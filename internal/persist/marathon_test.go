@@ -0,0 +1,74 @@
+package persist
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"goldsplit/internal/split"
+)
+
+func TestCreditMarathonLegs(t *testing.T) {
+	store := tempStore(t)
+
+	g1 := split.NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+	g2 := split.NewAttempts("a-2", "t-2", "", "Any%", []string{"C"})
+	stale := split.NewAttempts("a-3", "t-3", "", "Any%", []string{"D", "E"})
+
+	for _, att := range []*split.Attempts{g1, g2, stale} {
+		if err := store.SaveAttempts(att); err != nil {
+			t.Fatalf("save failed: %v", err)
+		}
+	}
+
+	legs := []split.MarathonLeg{
+		{AttemptsID: "a-1", SegmentCount: 2},
+		{AttemptsID: "a-2", SegmentCount: 1},
+		{AttemptsID: "a-3", SegmentCount: 1}, // Edited since the marathon was built.
+	}
+
+	end := split.AttemptEnd{Reason: split.EndFinished, Segment: 3, StartedAt: time.Now(), EndedAt: time.Now()}
+
+	credited, err := store.CreditMarathonLegs(legs, []int64{1000, 3000, 5000, 6000}, true, end)
+	if err != nil {
+		t.Fatalf("credit failed: %v", err)
+	}
+
+	if !slices.Equal(credited, []string{"a-1", "a-2"}) {
+		t.Fatalf("unexpected credited legs: %v", credited)
+	}
+
+	loaded, err := store.LoadAttempts("a-2")
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	if pb := loaded.PersonalBestSplits(); !slices.Equal(pb, []int64{2000}) {
+		t.Fatalf("expected leg PB of 2000, got %v", pb)
+	}
+}
+
+func TestCreditMarathonLegsSkipsMissingEntry(t *testing.T) {
+	store := tempStore(t)
+
+	g2 := split.NewAttempts("a-2", "t-2", "", "Any%", []string{"C"})
+	if err := store.SaveAttempts(g2); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	legs := []split.MarathonLeg{
+		{AttemptsID: "a-1", SegmentCount: 2}, // Deleted since the marathon was built.
+		{AttemptsID: "a-2", SegmentCount: 1},
+	}
+
+	end := split.AttemptEnd{Reason: split.EndFinished, Segment: 2, StartedAt: time.Now(), EndedAt: time.Now()}
+
+	credited, err := store.CreditMarathonLegs(legs, []int64{1000, 3000, 5000}, true, end)
+	if err == nil {
+		t.Fatal("expected an error for the missing leg")
+	}
+
+	if !slices.Equal(credited, []string{"a-2"}) {
+		t.Fatalf("expected the readable leg to be credited, got %v", credited)
+	}
+}
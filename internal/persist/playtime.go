@@ -0,0 +1,82 @@
+package persist
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"goldsplit/internal/split"
+)
+
+// CategoryPlayTime is the play time of a single attempts entry.
+type CategoryPlayTime struct {
+	AttemptsID   string         `json:"attemptsId"`
+	CategoryName string         `json:"categoryName"`
+	PlayTime     split.PlayTime `json:"playTime"`
+}
+
+// TemplatePlayTime rolls up the play time of every category of a template.
+type TemplatePlayTime struct {
+	TemplateID string             `json:"templateId"`
+	Name       string             `json:"name"`
+	PlayTime   split.PlayTime     `json:"playTime"`
+	Categories []CategoryPlayTime `json:"categories"`
+}
+
+// PlayTimeSummary is the play time across the whole store.
+type PlayTimeSummary struct {
+	Total     split.PlayTime     `json:"total"`
+	Templates []TemplatePlayTime `json:"templates"`
+}
+
+// PlayTimeSummary totals play time per category, per template and across the store.
+// Attempts whose template no longer exists are counted in the total only.
+func (s *Store) PlayTimeSummary(includePause bool) (PlayTimeSummary, error) {
+	var summary PlayTimeSummary
+
+	templates, err := s.ListTemplates()
+	if err != nil {
+		return summary, err
+	}
+
+	byTemplate := make(map[string]int, len(templates))
+	for _, tmpl := range templates {
+		byTemplate[tmpl.ID] = len(summary.Templates)
+		summary.Templates = append(summary.Templates, TemplatePlayTime{TemplateID: tmpl.ID, Name: tmpl.Name})
+	}
+
+	entries, err := os.ReadDir(filepath.Join(s.profileDir, "attempts"))
+	if err != nil {
+		return summary, fmt.Errorf("reading attempts directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		att, err := s.LoadAttempts(strings.TrimSuffix(entry.Name(), ".json"))
+		if err != nil {
+			continue
+		}
+
+		pt := att.PlayTime(includePause)
+		summary.Total.Add(pt)
+
+		idx, ok := byTemplate[att.TemplateID]
+		if !ok {
+			continue
+		}
+
+		tp := &summary.Templates[idx]
+		tp.PlayTime.Add(pt)
+		tp.Categories = append(tp.Categories, CategoryPlayTime{
+			AttemptsID:   att.ID,
+			CategoryName: att.CategoryName,
+			PlayTime:     pt,
+		})
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,69 @@
+package persist
+
+import (
+	"testing"
+
+	"goldsplit/internal/split"
+)
+
+func TestPlayTimeSummary(t *testing.T) {
+	store := tempStore(t)
+
+	if err := store.SaveTemplate(split.NewTemplate("t-1", "Game A", []string{"A", "B"})); err != nil {
+		t.Fatalf("save template failed: %v", err)
+	}
+
+	if err := store.SaveTemplate(split.NewTemplate("t-2", "Game B", []string{"A"})); err != nil {
+		t.Fatalf("save template failed: %v", err)
+	}
+
+	anyPct := split.NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+	anyPct.RecordAttempt([]int64{1000, 2000}, true, split.AttemptEnd{Reason: split.EndFinished, ElapsedMS: 2000, PausedMS: 500})
+	anyPct.RecordAttempt([]int64{1000}, false, split.AttemptEnd{Reason: split.EndReset, ElapsedMS: 1500})
+
+	hundred := split.NewAttempts("a-2", "t-1", "", "100%", []string{"A", "B"})
+	hundred.RecordAttempt([]int64{3000}, false, split.AttemptEnd{Reason: split.EndReset, ElapsedMS: 4000})
+
+	other := split.NewAttempts("a-3", "t-2", "", "Any%", []string{"A"})
+	other.AddAttempt([]int64{700}, true) // No elapsed recorded: falls back to the last split.
+
+	for _, att := range []*split.Attempts{anyPct, hundred, other} {
+		if err := store.SaveAttempts(att); err != nil {
+			t.Fatalf("save attempts failed: %v", err)
+		}
+	}
+
+	summary, err := store.PlayTimeSummary(false)
+	if err != nil {
+		t.Fatalf("summary failed: %v", err)
+	}
+
+	if summary.Total.Attempts != 4 || summary.Total.TotalMS != 2000+1500+4000+700 {
+		t.Fatalf("unexpected total: %+v", summary.Total)
+	}
+
+	var gameA TemplatePlayTime
+
+	for _, tp := range summary.Templates {
+		if tp.TemplateID == "t-1" {
+			gameA = tp
+		}
+	}
+
+	if len(gameA.Categories) != 2 {
+		t.Fatalf("expected 2 categories for Game A, got %d", len(gameA.Categories))
+	}
+
+	if gameA.PlayTime.TotalMS != 7500 || gameA.PlayTime.CompletedMS != 2000 || gameA.PlayTime.ResetMS != 5500 {
+		t.Fatalf("unexpected Game A play time: %+v", gameA.PlayTime)
+	}
+
+	withPause, err := store.PlayTimeSummary(true)
+	if err != nil {
+		t.Fatalf("summary failed: %v", err)
+	}
+
+	if withPause.Total.TotalMS != summary.Total.TotalMS+500 {
+		t.Fatalf("expected pause time to be added, got %d", withPause.Total.TotalMS)
+	}
+}
@@ -0,0 +1,242 @@
+package persist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+)
+
+// DefaultProfileID is the profile that always exists. Its data lives directly
+// in the base directory, where it was kept before profiles existed.
+const DefaultProfileID = "default"
+
+// ErrUnknownProfile is returned for a profile ID that does not exist.
+var ErrUnknownProfile = errors.New("unknown profile")
+
+// Profile is one runner sharing the install. Each profile has its own
+// settings, hotkeys, categories and history; templates are shared.
+type Profile struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// profileList is the profiles.json file.
+type profileList struct {
+	Active   string    `json:"active"`
+	Profiles []Profile `json:"profiles"`
+}
+
+// ActiveProfile returns the ID of the profile the store reads and writes.
+func (s *Store) ActiveProfile() string {
+	return s.profile
+}
+
+// ListProfiles returns every profile, the default one first.
+func (s *Store) ListProfiles() ([]Profile, error) {
+	list, err := s.loadProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	return list.Profiles, nil
+}
+
+// CreateProfile adds a profile with empty history and default settings.
+func (s *Store) CreateProfile(id, name string) (*Profile, error) {
+	list, err := s.loadProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	if !validProfileID(id) || list.find(id) >= 0 {
+		return nil, fmt.Errorf("invalid profile ID %q", id)
+	}
+
+	p := Profile{ID: id, Name: name, CreatedAt: time.Now()}
+	list.Profiles = append(list.Profiles, p)
+
+	if err := makeDirs(s.dirOf(id), "attempts", "il", "practice"); err != nil {
+		return nil, err
+	}
+
+	if err := s.saveProfiles(list); err != nil {
+		return nil, err
+	}
+
+	return &p, nil
+}
+
+// RenameProfile changes a profile's display name.
+func (s *Store) RenameProfile(id, name string) error {
+	list, err := s.loadProfiles()
+	if err != nil {
+		return err
+	}
+
+	i := list.find(id)
+	if i < 0 {
+		return fmt.Errorf("%w: %q", ErrUnknownProfile, id)
+	}
+
+	list.Profiles[i].Name = name
+
+	return s.saveProfiles(list)
+}
+
+// SwitchProfile makes the store read and write the given profile's data, and
+// remembers it as the profile to open next time.
+func (s *Store) SwitchProfile(id string) error {
+	list, err := s.loadProfiles()
+	if err != nil {
+		return err
+	}
+
+	if list.find(id) < 0 {
+		return fmt.Errorf("%w: %q", ErrUnknownProfile, id)
+	}
+
+	if err := s.openProfile(id); err != nil {
+		return err
+	}
+
+	list.Active = id
+
+	return s.saveProfiles(list)
+}
+
+// DeleteProfile removes a profile and all of its data. The default and the
+// active profile cannot be deleted.
+func (s *Store) DeleteProfile(id string) error {
+	if id == DefaultProfileID || id == s.profile {
+		return fmt.Errorf("cannot delete profile %q", id)
+	}
+
+	list, err := s.loadProfiles()
+	if err != nil {
+		return err
+	}
+
+	i := list.find(id)
+	if i < 0 {
+		return fmt.Errorf("%w: %q", ErrUnknownProfile, id)
+	}
+
+	list.Profiles = slices.Delete(list.Profiles, i, i+1)
+
+	if err := s.saveProfiles(list); err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(s.dirOf(id)); err != nil {
+		return fmt.Errorf("deleting profile directory: %w", err)
+	}
+
+	return nil
+}
+
+// ProfilesUsingTemplate returns the profiles other than the active one that
+// have categories on the given template.
+func (s *Store) ProfilesUsingTemplate(templateID string) ([]Profile, error) {
+	list, err := s.loadProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	var using []Profile
+
+	for _, p := range list.Profiles {
+		if p.ID != s.profile && len(templateAttemptFiles(s.dirOf(p.ID), templateID)) > 0 {
+			using = append(using, p)
+		}
+	}
+
+	return using, nil
+}
+
+// openProfile points the store at a profile's directory, creating it if needed.
+func (s *Store) openProfile(id string) error {
+	dir := s.dirOf(id)
+	if err := makeDirs(dir, "attempts", "il", "practice"); err != nil {
+		return err
+	}
+
+	s.profile = id
+	s.profileDir = dir
+
+	return nil
+}
+
+func (s *Store) dirOf(id string) string {
+	if id == DefaultProfileID {
+		return s.baseDir
+	}
+
+	return filepath.Join(s.baseDir, "profiles", id)
+}
+
+// loadProfiles reads profiles.json. A missing file, or one without the default
+// profile, yields the default profile.
+func (s *Store) loadProfiles() (*profileList, error) {
+	list := &profileList{}
+
+	data, err := os.ReadFile(s.profilesPath())
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("reading profiles file: %w", err)
+	}
+
+	if err == nil {
+		if err := json.Unmarshal(data, list); err != nil {
+			return nil, fmt.Errorf("unmarshaling profiles: %w", err)
+		}
+	}
+
+	if list.find(DefaultProfileID) < 0 {
+		list.Profiles = slices.Insert(list.Profiles, 0, Profile{ID: DefaultProfileID, Name: "Default"})
+	}
+
+	if list.find(list.Active) < 0 {
+		list.Active = DefaultProfileID
+	}
+
+	return list, nil
+}
+
+func (s *Store) saveProfiles(list *profileList) error {
+	data, err := json.MarshalIndent(list, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling profiles: %w", err)
+	}
+
+	path := s.profilesPath()
+	tmpPath := path + ".tmp"
+
+	if err := os.WriteFile(tmpPath, data, 0o640); err != nil {
+		return fmt.Errorf("writing temp file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+
+		return fmt.Errorf("renaming temp file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *Store) profilesPath() string {
+	return filepath.Join(s.baseDir, "profiles.json")
+}
+
+// validProfileID reports whether id can name a directory under profiles/.
+func validProfileID(id string) bool {
+	return id != "" && id != "." && id != ".." && filepath.Base(id) == id
+}
+
+func (l *profileList) find(id string) int {
+	return slices.IndexFunc(l.Profiles, func(p Profile) bool { return p.ID == id })
+}
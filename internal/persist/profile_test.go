@@ -0,0 +1,171 @@
+package persist
+
+import (
+	"errors"
+	"testing"
+
+	"goldsplit/internal/split"
+)
+
+func TestProfilesKeepHistoryApart(t *testing.T) {
+	store := tempStore(t)
+
+	if store.ActiveProfile() != DefaultProfileID {
+		t.Fatalf("expected default profile, got %q", store.ActiveProfile())
+	}
+
+	tmpl := split.NewTemplate("t-1", "Game", []string{"A"})
+	if err := store.SaveTemplate(tmpl); err != nil {
+		t.Fatalf("save template failed: %v", err)
+	}
+
+	if err := store.SaveAttempts(split.NewAttempts("a-1", "t-1", "", "Any%", []string{"A"})); err != nil {
+		t.Fatalf("save attempts failed: %v", err)
+	}
+
+	if _, err := store.CreateProfile("p-2", "Second Runner"); err != nil {
+		t.Fatalf("create profile failed: %v", err)
+	}
+
+	if err := store.SwitchProfile("p-2"); err != nil {
+		t.Fatalf("switch failed: %v", err)
+	}
+
+	// Templates are shared, attempts are not.
+	if _, err := store.LoadTemplate("t-1"); err != nil {
+		t.Fatalf("expected shared template: %v", err)
+	}
+
+	if _, err := store.LoadAttempts("a-1"); err == nil {
+		t.Fatal("expected attempts of another profile to be hidden")
+	}
+
+	settings := DefaultSettings()
+	settings.Hotkeys.StartSplit = "Enter"
+
+	if err := store.SaveSettings(settings); err != nil {
+		t.Fatalf("save settings failed: %v", err)
+	}
+
+	if err := store.SwitchProfile(DefaultProfileID); err != nil {
+		t.Fatalf("switch back failed: %v", err)
+	}
+
+	loaded, err := store.LoadSettings()
+	if err != nil {
+		t.Fatalf("load settings failed: %v", err)
+	}
+
+	if loaded.Hotkeys.StartSplit != DefaultSettings().Hotkeys.StartSplit {
+		t.Fatalf("settings leaked between profiles: %q", loaded.Hotkeys.StartSplit)
+	}
+}
+
+func TestActiveProfileSurvivesReopen(t *testing.T) {
+	dir := t.TempDir()
+
+	store, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("create store failed: %v", err)
+	}
+
+	if _, err := store.CreateProfile("p-2", "Second Runner"); err != nil {
+		t.Fatalf("create profile failed: %v", err)
+	}
+
+	if err := store.SwitchProfile("p-2"); err != nil {
+		t.Fatalf("switch failed: %v", err)
+	}
+
+	reopened, err := NewStore(dir)
+	if err != nil {
+		t.Fatalf("reopen failed: %v", err)
+	}
+
+	if reopened.ActiveProfile() != "p-2" {
+		t.Fatalf("expected p-2 after reopen, got %q", reopened.ActiveProfile())
+	}
+}
+
+func TestDeleteProfile(t *testing.T) {
+	store := tempStore(t)
+
+	if err := store.SwitchProfile("missing"); !errors.Is(err, ErrUnknownProfile) {
+		t.Fatalf("expected ErrUnknownProfile, got %v", err)
+	}
+
+	if _, err := store.CreateProfile("../escape", "Bad"); err == nil {
+		t.Fatal("expected path-like profile ID to be rejected")
+	}
+
+	if err := store.DeleteProfile(DefaultProfileID); err == nil {
+		t.Fatal("expected default profile deletion to fail")
+	}
+
+	if _, err := store.CreateProfile("p-2", "Second Runner"); err != nil {
+		t.Fatalf("create profile failed: %v", err)
+	}
+
+	if err := store.DeleteProfile("p-2"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+
+	profiles, err := store.ListProfiles()
+	if err != nil || len(profiles) != 1 {
+		t.Fatalf("expected only the default profile, got %v (%v)", profiles, err)
+	}
+}
+
+func TestDeleteTemplateInUseByOtherProfile(t *testing.T) {
+	store := tempStore(t)
+
+	if err := store.SaveTemplate(split.NewTemplate("t-1", "Game", []string{"A"})); err != nil {
+		t.Fatalf("save template failed: %v", err)
+	}
+
+	if _, err := store.CreateProfile("p-2", "Second Runner"); err != nil {
+		t.Fatalf("create profile failed: %v", err)
+	}
+
+	if err := store.SwitchProfile("p-2"); err != nil {
+		t.Fatalf("switch failed: %v", err)
+	}
+
+	if err := store.SaveAttempts(split.NewAttempts("a-2", "t-1", "", "Any%", []string{"A"})); err != nil {
+		t.Fatalf("save attempts failed: %v", err)
+	}
+
+	if err := store.SwitchProfile(DefaultProfileID); err != nil {
+		t.Fatalf("switch failed: %v", err)
+	}
+
+	using, err := store.ProfilesUsingTemplate("t-1")
+	if err != nil || len(using) != 1 || using[0].ID != "p-2" {
+		t.Fatalf("expected p-2 to use the template, got %v (%v)", using, err)
+	}
+
+	if err := store.DeleteTemplate("t-1"); !errors.Is(err, ErrTemplateInUse) {
+		t.Fatalf("expected ErrTemplateInUse, got %v", err)
+	}
+
+	if _, err := store.LoadTemplate("t-1"); err != nil {
+		t.Fatalf("expected the template to survive a refused delete: %v", err)
+	}
+
+	if err := store.SwitchProfile("p-2"); err != nil {
+		t.Fatalf("switch failed: %v", err)
+	}
+
+	if _, err := store.LoadAttempts("a-2"); err != nil {
+		t.Fatalf("expected the other profile's attempts to survive: %v", err)
+	}
+
+	// From the profile that owns the categories, the delete goes through.
+	if err := store.DeleteTemplate("t-1"); err != nil {
+		t.Fatalf("delete template failed: %v", err)
+	}
+
+	if _, err := store.LoadAttempts("a-2"); err == nil {
+		t.Fatal("expected the active profile's attempts to be deleted with the template")
+	}
+}
@@ -0,0 +1,65 @@
+package persist
+
+import (
+	"strings"
+
+	"goldsplit/internal/split"
+)
+
+// TemplateQuery filters templates by game metadata. Empty fields match anything.
+// Text matches the name, abbreviation or any external ID; the other fields must
+// equal the template's value. All comparisons ignore case.
+type TemplateQuery struct {
+	Text     string `json:"text"`
+	Platform string `json:"platform"`
+	Region   string `json:"region"`
+	Emulator string `json:"emulator"`
+}
+
+// Matches reports whether a template summary satisfies the query.
+func (q TemplateQuery) Matches(t TemplateSummary) bool {
+	if !matchField(q.Platform, t.Game.Platform) || !matchField(q.Region, t.Game.Region) ||
+		!matchField(q.Emulator, t.Game.Emulator) {
+		return false
+	}
+
+	return q.Text == "" || matchesText(q.Text, t.Name, t.Game)
+}
+
+// SearchTemplates returns the templates matching q.
+func (s *Store) SearchTemplates(q TemplateQuery) ([]TemplateSummary, error) {
+	summaries, err := s.ListTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []TemplateSummary
+
+	for _, t := range summaries {
+		if q.Matches(t) {
+			matches = append(matches, t)
+		}
+	}
+
+	return matches, nil
+}
+
+func matchField(want, have string) bool {
+	return want == "" || strings.EqualFold(want, have)
+}
+
+func matchesText(text, name string, game split.GameInfo) bool {
+	text = strings.ToLower(text)
+
+	if strings.Contains(strings.ToLower(name), text) || strings.EqualFold(game.Abbreviation, text) {
+		return true
+	}
+
+	for _, id := range game.ExternalIDs {
+		if strings.EqualFold(id, text) {
+			return true
+		}
+	}
+
+	return false
+}
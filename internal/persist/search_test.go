@@ -0,0 +1,75 @@
+package persist
+
+import (
+	"testing"
+
+	"goldsplit/internal/split"
+)
+
+func TestSearchTemplates(t *testing.T) {
+	store := tempStore(t)
+
+	sm64 := split.NewTemplate("t-1", "Super Mario 64", []string{"A"})
+	sm64.Game = split.GameInfo{
+		Platform:     "N64",
+		Region:       "NTSC-J",
+		Abbreviation: "sm64",
+		ExternalIDs:  map[string]string{"speedruncom": "o1y9wo6q"},
+	}
+
+	oot := split.NewTemplate("t-2", "Ocarina of Time", []string{"A"})
+	oot.Game = split.GameInfo{Platform: "N64", Region: "NTSC-U", Emulator: "Project64"}
+
+	for _, tmpl := range []*split.Template{sm64, oot} {
+		if err := store.SaveTemplate(tmpl); err != nil {
+			t.Fatalf("save failed: %v", err)
+		}
+	}
+
+	cases := []struct {
+		name  string
+		query TemplateQuery
+		want  []string
+	}{
+		{name: "platform", query: TemplateQuery{Platform: "n64"}, want: []string{"t-1", "t-2"}},
+		{name: "region", query: TemplateQuery{Region: "NTSC-U"}, want: []string{"t-2"}},
+		{name: "emulator", query: TemplateQuery{Emulator: "project64"}, want: []string{"t-2"}},
+		{name: "name", query: TemplateQuery{Text: "mario"}, want: []string{"t-1"}},
+		{name: "abbreviation", query: TemplateQuery{Text: "SM64"}, want: []string{"t-1"}},
+		{name: "external ID", query: TemplateQuery{Text: "o1y9wo6q"}, want: []string{"t-1"}},
+		{name: "no match", query: TemplateQuery{Platform: "PC"}, want: nil},
+	}
+
+	for _, tc := range cases {
+		got, err := store.SearchTemplates(tc.query)
+		if err != nil {
+			t.Fatalf("%s: search failed: %v", tc.name, err)
+		}
+
+		if len(got) != len(tc.want) {
+			t.Fatalf("%s: expected %v, got %+v", tc.name, tc.want, got)
+		}
+
+		for i, id := range tc.want {
+			if got[i].ID != id {
+				t.Fatalf("%s: expected %v, got %+v", tc.name, tc.want, got)
+			}
+		}
+	}
+}
+
+func TestListTemplatesIncludesGame(t *testing.T) {
+	store := tempStore(t)
+
+	tmpl := split.NewTemplate("t-1", "Game", []string{"A"})
+	tmpl.Game.Platform = "PC"
+
+	if err := store.SaveTemplate(tmpl); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	summaries, err := store.ListTemplates()
+	if err != nil || len(summaries) != 1 || summaries[0].Game.Platform != "PC" {
+		t.Fatalf("expected game metadata in summary, got %+v, err %v", summaries, err)
+	}
+}
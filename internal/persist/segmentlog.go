@@ -0,0 +1,80 @@
+package persist
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"goldsplit/internal/split"
+)
+
+// SegmentLogKind names a single-segment run log. Each kind is kept in its own
+// directory, named after the kind, in the profile directory.
+type SegmentLogKind string
+
+const (
+	ILLog       SegmentLogKind = "il"       // Individual level runs.
+	PracticeLog SegmentLogKind = "practice" // Practice runs.
+)
+
+// segmentLogKinds lists every kind, for creating and cleaning up their directories.
+var segmentLogKinds = []SegmentLogKind{ILLog, PracticeLog}
+
+// SaveSegmentLog persists an attempts entry's runs of one kind to disk using atomic write.
+func (s *Store) SaveSegmentLog(kind SegmentLogKind, l *split.SegmentLog) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling %s log: %w", kind, err)
+	}
+
+	path := s.segmentLogPath(kind, l.AttemptsID)
+	tmpPath := path + ".tmp"
+
+	if err := os.WriteFile(tmpPath, data, 0o640); err != nil {
+		return fmt.Errorf("writing temp file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+
+		return fmt.Errorf("renaming temp file: %w", err)
+	}
+
+	return nil
+}
+
+// LoadSegmentLog reads an attempts entry's runs of one kind. Returns an empty
+// log if none exist yet.
+func (s *Store) LoadSegmentLog(kind SegmentLogKind, attemptsID string) (*split.SegmentLog, error) {
+	data, err := os.ReadFile(s.segmentLogPath(kind, attemptsID))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return split.NewSegmentLog(attemptsID), nil
+		}
+
+		return nil, fmt.Errorf("reading %s log file: %w", kind, err)
+	}
+
+	var l split.SegmentLog
+	if err := json.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("unmarshaling %s log: %w", kind, err)
+	}
+
+	return &l, nil
+}
+
+// DeleteSegmentLog removes an attempts entry's runs of one kind. No-op if there are none.
+func (s *Store) DeleteSegmentLog(kind SegmentLogKind, attemptsID string) error {
+	err := os.Remove(s.segmentLogPath(kind, attemptsID))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("deleting %s log file: %w", kind, err)
+	}
+
+	return nil
+}
+
+func (s *Store) segmentLogPath(kind SegmentLogKind, attemptsID string) string {
+	return filepath.Join(s.profileDir, string(kind), attemptsID+".json")
+}
@@ -0,0 +1,70 @@
+package persist
+
+import (
+	"testing"
+	"time"
+
+	"goldsplit/internal/split"
+)
+
+func TestSegmentLogRoundTrip(t *testing.T) {
+	store := tempStore(t)
+
+	empty, err := store.LoadSegmentLog(ILLog, "a-1")
+	if err != nil || len(empty.Runs) != 0 || empty.AttemptsID != "a-1" {
+		t.Fatalf("expected empty log, got %+v, err %v", empty, err)
+	}
+
+	empty.RecordIL(1, 5000, time.Now())
+
+	if err := store.SaveSegmentLog(ILLog, empty); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	loaded, err := store.LoadSegmentLog(ILLog, "a-1")
+	if err != nil || len(loaded.Runs) != 1 || loaded.Runs[0].TimeMS != 5000 {
+		t.Fatalf("unexpected log: %+v, err %v", loaded, err)
+	}
+
+	// Each kind is kept apart.
+	practice, err := store.LoadSegmentLog(PracticeLog, "a-1")
+	if err != nil || len(practice.Runs) != 0 {
+		t.Fatalf("expected an empty practice log, got %+v, err %v", practice, err)
+	}
+
+	if err := store.DeleteSegmentLog(ILLog, "a-1"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+
+	if err := store.DeleteSegmentLog(ILLog, "a-1"); err != nil {
+		t.Fatalf("expected deleting a missing log to succeed: %v", err)
+	}
+}
+
+func TestDeleteAttemptsRemovesSegmentLogs(t *testing.T) {
+	store := tempStore(t)
+
+	if err := store.SaveAttempts(split.NewAttempts("a-1", "t-1", "", "Any%", []string{"A"})); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	l := split.NewSegmentLog("a-1")
+	l.RecordPractice(1, 1000, 0, time.Now())
+
+	for _, kind := range []SegmentLogKind{ILLog, PracticeLog} {
+		if err := store.SaveSegmentLog(kind, l); err != nil {
+			t.Fatalf("save failed: %v", err)
+		}
+	}
+
+	if err := store.DeleteAttempts("a-1"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+
+	for _, kind := range []SegmentLogKind{ILLog, PracticeLog} {
+		loaded, err := store.LoadSegmentLog(kind, "a-1")
+		if err != nil || len(loaded.Runs) != 0 {
+			t.Fatalf("expected the %s log to be removed, got %+v, err %v", kind, loaded, err)
+		}
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"goldsplit/internal/split"
 )
 
 // ColorSettings holds the hex color values for delta display.
@@ -22,6 +24,16 @@ type Settings struct {
 	Hotkeys     HotkeyBindings `json:"hotkeys"`
 	Comparison  string         `json:"comparison"`
 	Colors      ColorSettings  `json:"colors"`
+
+	SessionIdleGapMinutes  int  `json:"sessionIdleGapMinutes"`  // Idle time before the next attempt starts a new session.
+	PlayTimeIncludesPause  bool `json:"playTimeIncludesPause"`  // Count paused time towards total play time.
+	CollapseFinishedGroups bool `json:"collapseFinishedGroups"` // Show finished subsplit groups as a single row.
+	PartialStartGolds      bool `json:"partialStartGolds"`      // Let runs started from a later segment set golds.
+	CountdownSeconds       int  `json:"countdownSeconds"`       // Length of the race countdown started from the hotkey.
+	AutoLockWhenIdle       bool `json:"autoLockWhenIdle"`       // Lock input whenever the timer stops running.
+
+	ResetSafeguards split.ResetSafeguards `json:"resetSafeguards"`
+	Alerts          split.AlertConfig     `json:"alerts"` // Pace alerts emitted during a run.
 }
 
 // HotkeyBindings holds the key bindings for each action.
@@ -32,6 +44,10 @@ type HotkeyBindings struct {
 	Reset      string `json:"reset"`
 	UndoSplit  string `json:"undoSplit"`
 	SkipSplit  string `json:"skipSplit"`
+	Hit        string `json:"hit"`
+	UndoHit    string `json:"undoHit"`
+	Countdown  string `json:"countdown"`
+	ToggleLock string `json:"toggleLock"`
 }
 
 // DefaultSettings returns the default settings for a fresh install.
@@ -44,6 +60,10 @@ func DefaultSettings() Settings {
 			Reset:      "KeyR",
 			UndoSplit:  "Backspace",
 			SkipSplit:  "KeyS",
+			Hit:        "KeyH",
+			UndoHit:    "KeyJ",
+			Countdown:  "KeyC",
+			ToggleLock: "KeyL",
 		},
 		Comparison: "personal_best",
 		Colors: ColorSettings{
@@ -53,6 +73,17 @@ func DefaultSettings() Settings {
 			BehindLosing:  "#ff453a",
 			BestTime:      "#ffd60a",
 		},
+		SessionIdleGapMinutes: 30,
+		CountdownSeconds:      5,
+		ResetSafeguards: split.ResetSafeguards{
+			Mode:                "confirm",
+			DoublePressWindowMS: 2000,
+		},
+		Alerts: split.AlertConfig{
+			AheadOfPB:    true,
+			PBImpossible: true,
+			GoldPace:     true,
+		},
 	}
 }
 
@@ -101,5 +132,5 @@ func (s *Store) SaveSettings(settings Settings) error {
 }
 
 func (s *Store) settingsPath() string {
-	return filepath.Join(s.baseDir, "settings.json")
+	return filepath.Join(s.profileDir, "settings.json")
 }
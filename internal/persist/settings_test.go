@@ -110,3 +110,22 @@ func TestSettingsAtomicWrite(t *testing.T) {
 		}
 	}
 }
+
+func TestSettingsPartialResetSafeguards(t *testing.T) {
+	store := tempStore(t)
+
+	partial := []byte(`{"resetSafeguards": {"whenAheadOfPb": true}}`)
+	if err := os.WriteFile(store.settingsPath(), partial, 0o640); err != nil {
+		t.Fatalf("write failed: %v", err)
+	}
+
+	loaded, err := store.LoadSettings()
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	guard := loaded.ResetSafeguards
+	if !guard.WhenAheadOfPB || guard.Mode != "confirm" || guard.DoublePressWindowMS != 2000 {
+		t.Fatalf("expected missing safeguard fields to keep defaults, got %+v", guard)
+	}
+}
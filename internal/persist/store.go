@@ -2,29 +2,58 @@ package persist
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
 	"goldsplit/internal/split"
 )
 
+// ErrTemplateInUse is returned when deleting a template other profiles still
+// have categories on.
+var ErrTemplateInUse = errors.New("template in use by other profiles")
+
 // Store handles persistence of templates and attempts to disk.
+// Templates and assets are shared; everything else belongs to the active profile.
 type Store struct {
-	baseDir string
+	baseDir    string
+	profile    string
+	profileDir string
 }
 
-// NewStore creates a store at the given base directory.
+// NewStore creates a store at the given base directory, opened on the profile
+// that was active last.
 func NewStore(baseDir string) (*Store, error) {
-	for _, sub := range []string{"templates", "attempts"} {
+	if err := makeDirs(baseDir, "templates", "assets"); err != nil {
+		return nil, err
+	}
+
+	s := &Store{baseDir: baseDir}
+
+	list, err := s.loadProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.openProfile(list.Active); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func makeDirs(baseDir string, subs ...string) error {
+	for _, sub := range subs {
 		dir := filepath.Join(baseDir, sub)
 		if err := os.MkdirAll(dir, 0o750); err != nil {
-			return nil, fmt.Errorf("creating %s directory: %w", sub, err)
+			return fmt.Errorf("creating %s directory: %w", sub, err)
 		}
 	}
 
-	return &Store{baseDir: baseDir}, nil
+	return nil
 }
 
 // DefaultBaseDir returns the default application data directory.
@@ -39,10 +68,11 @@ func DefaultBaseDir() (string, error) {
 
 // TemplateSummary is a lightweight representation of a template for listing.
 type TemplateSummary struct {
-	ID           string `json:"id"`
-	Name         string `json:"name"`
-	SegmentCount int    `json:"segmentCount"`
-	UpdatedAt    int64  `json:"updatedAt"`
+	ID           string         `json:"id"`
+	Name         string         `json:"name"`
+	SegmentCount int            `json:"segmentCount"`
+	UpdatedAt    int64          `json:"updatedAt"`
+	Game         split.GameInfo `json:"game"`
 }
 
 // AttemptsSummary is a lightweight representation of attempts for listing.
@@ -53,6 +83,7 @@ type AttemptsSummary struct {
 	CategoryName string `json:"categoryName"`
 	AttemptCount int    `json:"attemptCount"`
 	UpdatedAt    int64  `json:"updatedAt"`
+	OutOfSync    bool   `json:"outOfSync"` // Segments differ from the template's current segment names.
 }
 
 // SaveTemplate persists a template to disk using atomic write.
@@ -121,34 +152,38 @@ func (s *Store) ListTemplates() ([]TemplateSummary, error) {
 			Name:         tmpl.Name,
 			SegmentCount: len(tmpl.SegmentNames),
 			UpdatedAt:    tmpl.UpdatedAt.Unix(),
+			Game:         tmpl.Game,
 		})
 	}
 
 	return summaries, nil
 }
 
-// DeleteTemplate removes a template and all associated attempts from disk.
+// DeleteTemplate removes a template and the active profile's attempts on it
+// from disk. It refuses with ErrTemplateInUse while other profiles have
+// categories on the template, so one runner cannot wipe another's history.
+// Its images are left for PruneAssets.
 func (s *Store) DeleteTemplate(id string) error {
-	// Delete associated attempts first.
-	attDir := filepath.Join(s.baseDir, "attempts")
+	others, err := s.ProfilesUsingTemplate(id)
+	if err != nil {
+		return err
+	}
 
-	entries, err := os.ReadDir(attDir)
-	if err == nil {
-		for _, entry := range entries {
-			if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
-				continue
-			}
+	if len(others) > 0 {
+		names := make([]string, len(others))
+		for i, p := range others {
+			names[i] = p.Name
+		}
 
-			attID := strings.TrimSuffix(entry.Name(), ".json")
+		return fmt.Errorf("%w: %s", ErrTemplateInUse, strings.Join(names, ", "))
+	}
 
-			att, loadErr := s.LoadAttempts(attID)
-			if loadErr != nil {
-				continue
-			}
+	// Delete associated attempts first.
+	for _, name := range templateAttemptFiles(s.profileDir, id) {
+		_ = os.Remove(filepath.Join(s.profileDir, "attempts", name))
 
-			if att.TemplateID == id {
-				_ = os.Remove(s.attemptsPath(attID))
-			}
+		for _, kind := range segmentLogKinds {
+			_ = os.Remove(filepath.Join(s.profileDir, string(kind), name))
 		}
 	}
 
@@ -194,18 +229,26 @@ func (s *Store) LoadAttempts(id string) (*split.Attempts, error) {
 		return nil, fmt.Errorf("unmarshaling attempts: %w", err)
 	}
 
+	att.EnsureSegmentIDs()
+
 	return &att, nil
 }
 
 // ListAttemptsForTemplate returns all attempts for a given template.
 func (s *Store) ListAttemptsForTemplate(templateID string) ([]AttemptsSummary, error) {
-	dir := filepath.Join(s.baseDir, "attempts")
+	dir := filepath.Join(s.profileDir, "attempts")
 
 	entries, err := os.ReadDir(dir)
 	if err != nil {
 		return nil, fmt.Errorf("reading attempts directory: %w", err)
 	}
 
+	// The template may be missing for orphaned attempts; treat those as in sync.
+	var templateNames []string
+	if tmpl, err := s.LoadTemplate(templateID); err == nil {
+		templateNames = tmpl.SegmentNames
+	}
+
 	var summaries []AttemptsSummary
 
 	for _, entry := range entries {
@@ -231,25 +274,65 @@ func (s *Store) ListAttemptsForTemplate(templateID string) ([]AttemptsSummary, e
 			CategoryName: att.CategoryName,
 			AttemptCount: att.AttemptCount,
 			UpdatedAt:    att.UpdatedAt.Unix(),
+			OutOfSync:    templateNames != nil && !slices.Equal(att.SegmentNames(), templateNames),
 		})
 	}
 
 	return summaries, nil
 }
 
-// DeleteAttempts removes an attempts file from disk.
+// DeleteAttempts removes an attempts file with its segment logs from disk.
 func (s *Store) DeleteAttempts(id string) error {
 	if err := os.Remove(s.attemptsPath(id)); err != nil {
 		return fmt.Errorf("deleting attempts file: %w", err)
 	}
 
+	for _, kind := range segmentLogKinds {
+		if err := s.DeleteSegmentLog(kind, id); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// deleteTemplateAttempts removes the attempts and segment logs of a template
+// from one profile directory.
+// templateAttemptFiles returns the names of the attempts files in a profile
+// directory that belong to the given template.
+func templateAttemptFiles(dir, templateID string) []string {
+	entries, err := os.ReadDir(filepath.Join(dir, "attempts"))
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(dir, "attempts", entry.Name()))
+		if err != nil {
+			continue
+		}
+
+		var att split.Attempts
+		if err := json.Unmarshal(data, &att); err != nil || att.TemplateID != templateID {
+			continue
+		}
+
+		names = append(names, entry.Name())
+	}
+
+	return names
+}
+
 func (s *Store) templatePath(id string) string {
 	return filepath.Join(s.baseDir, "templates", id+".json")
 }
 
 func (s *Store) attemptsPath(id string) string {
-	return filepath.Join(s.baseDir, "attempts", id+".json")
+	return filepath.Join(s.profileDir, "attempts", id+".json")
 }
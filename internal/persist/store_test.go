@@ -42,6 +42,46 @@ func TestSaveAndLoadTemplate(t *testing.T) {
 	}
 }
 
+func TestTemplateSegmentGroupsRoundTrip(t *testing.T) {
+	store := tempStore(t)
+
+	tmpl := split.NewTemplate("t-1", "Game", []string{"1-1", "1-2", "Boss"})
+	tmpl.SetSegmentGroups([]string{"World 1", "World 1", ""})
+
+	if err := store.SaveTemplate(tmpl); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	loaded, err := store.LoadTemplate("t-1")
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	if len(loaded.SegmentGroups) != 3 || loaded.SegmentGroups[1] != "World 1" || loaded.SegmentGroups[2] != "" {
+		t.Fatalf("unexpected segment groups: %v", loaded.SegmentGroups)
+	}
+}
+
+func TestSegmentNotesRoundTrip(t *testing.T) {
+	store := tempStore(t)
+
+	att := split.NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+	att.SetSegmentNotes(1, "- jump early")
+
+	if err := store.SaveAttempts(att); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	loaded, err := store.LoadAttempts("a-1")
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	if loaded.Segments[0].Notes != "" || loaded.Segments[1].Notes != "- jump early" {
+		t.Fatalf("unexpected segment notes: %+v", loaded.Segments)
+	}
+}
+
 func TestListTemplates(t *testing.T) {
 	store := tempStore(t)
 
@@ -157,6 +197,34 @@ func TestListAttemptsForTemplate(t *testing.T) {
 	}
 }
 
+func TestListAttemptsForTemplateOutOfSync(t *testing.T) {
+	store := tempStore(t)
+
+	if err := store.SaveTemplate(split.NewTemplate("t-1", "Game", []string{"A", "B", "C"})); err != nil {
+		t.Fatalf("save template failed: %v", err)
+	}
+
+	synced := split.NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	stale := split.NewAttempts("a-2", "t-1", "", "100%", []string{"A", "C"})
+
+	for _, a := range []*split.Attempts{synced, stale} {
+		if err := store.SaveAttempts(a); err != nil {
+			t.Fatalf("save failed: %v", err)
+		}
+	}
+
+	summaries, err := store.ListAttemptsForTemplate("t-1")
+	if err != nil {
+		t.Fatalf("list failed: %v", err)
+	}
+
+	for _, s := range summaries {
+		if s.OutOfSync != (s.ID == "a-2") {
+			t.Fatalf("%s: expected outOfSync %v, got %v", s.ID, s.ID == "a-2", s.OutOfSync)
+		}
+	}
+}
+
 func TestDeleteAttempts(t *testing.T) {
 	store := tempStore(t)
 
@@ -241,3 +309,29 @@ func TestSaveUpdatesRoundTrip(t *testing.T) {
 		t.Fatalf("expected PB 1000, got %v", pb)
 	}
 }
+
+func TestAttemptMetadataRoundTrip(t *testing.T) {
+	store := tempStore(t)
+
+	att := split.NewAttempts("a-1", "t-1", "", "Any%", []string{"A"})
+	att.AddAttempt([]int64{1000}, true)
+	att.EditAttemptMetadata(1, split.AttemptMetadata{
+		Notes:  "WR pace",
+		Tags:   []string{"race"},
+		Fields: map[string]string{"version": "1.1"},
+	})
+
+	if err := store.SaveAttempts(att); err != nil {
+		t.Fatalf("save failed: %v", err)
+	}
+
+	loaded, err := store.LoadAttempts("a-1")
+	if err != nil {
+		t.Fatalf("load failed: %v", err)
+	}
+
+	got := loaded.History[0]
+	if got.Notes != "WR pace" || !got.HasTag("race") || got.Fields["version"] != "1.1" {
+		t.Fatalf("expected metadata to round-trip, got %+v", got.AttemptMetadata)
+	}
+}
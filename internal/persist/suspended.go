@@ -17,6 +17,10 @@ type SuspendedRun struct {
 	SplitTimesMS   []int64 `json:"splitTimesMs"`
 	SegmentTimesMS []int64 `json:"segmentTimesMs"`
 	SuspendedAt    int64   `json:"suspendedAt"`
+	StartedAt      int64   `json:"startedAt,omitempty"`    // Wall-clock start of the run (Unix seconds).
+	Explicit       bool    `json:"explicit"`               // False for mid-run checkpoints, which a crash can leave behind.
+	StartSegment   int     `json:"startSegment,omitempty"` // Segment a partial-start run began on.
+	Hits           []int   `json:"hits,omitempty"`         // Hits per segment of a hit-counted run.
 }
 
 // SaveSuspendedRun persists a suspended run to disk using atomic write.
@@ -73,5 +77,5 @@ func (s *Store) DeleteSuspendedRun() error {
 }
 
 func (s *Store) suspendedRunPath() string {
-	return filepath.Join(s.baseDir, "suspended_run.json")
+	return filepath.Join(s.profileDir, "suspended_run.json")
 }
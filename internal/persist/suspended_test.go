@@ -15,6 +15,8 @@ func TestSuspendedRunRoundTrip(t *testing.T) {
 		SplitTimesMS:   []int64{1000, 3000},
 		SegmentTimesMS: []int64{1000, 2000},
 		SuspendedAt:    1700000000,
+		StartedAt:      1699999000,
+		Explicit:       true,
 	}
 
 	if err := store.SaveSuspendedRun(run); err != nil {
@@ -61,6 +63,14 @@ func TestSuspendedRunRoundTrip(t *testing.T) {
 	if loaded.SuspendedAt != run.SuspendedAt {
 		t.Fatalf("suspendedAt: got %d, want %d", loaded.SuspendedAt, run.SuspendedAt)
 	}
+
+	if loaded.StartedAt != run.StartedAt {
+		t.Fatalf("startedAt: got %d, want %d", loaded.StartedAt, run.StartedAt)
+	}
+
+	if !loaded.Explicit {
+		t.Fatal("explicit: got false, want true")
+	}
 }
 
 func TestLoadSuspendedRunMissing(t *testing.T) {
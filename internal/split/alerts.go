@@ -0,0 +1,122 @@
+package split
+
+// AlertKind names a pace alert.
+type AlertKind string
+
+const (
+	AlertAheadOfPB    AlertKind = "ahead_of_pb"   // The run just moved ahead of PB.
+	AlertPBImpossible AlertKind = "pb_impossible" // Even golds in every remaining segment can no longer beat PB.
+	AlertGoldPace     AlertKind = "gold_pace"     // The segment just split was a gold.
+	AlertBehindBest   AlertKind = "behind_best"   // The run just fell the configured time behind sum of best.
+)
+
+// AlertConfig selects which pace alerts fire.
+type AlertConfig struct {
+	AheadOfPB    bool  `json:"aheadOfPb"`
+	PBImpossible bool  `json:"pbImpossible"`
+	GoldPace     bool  `json:"goldPace"`
+	BehindBestMS int64 `json:"behindBestMs"` // Threshold behind the best-segments pace; 0 disables.
+}
+
+// Alert is a milestone reached by the latest split of a run.
+type Alert struct {
+	Kind         AlertKind `json:"kind"`
+	SegmentIndex int       `json:"segmentIndex"`
+	DeltaMS      int64     `json:"deltaMs"` // Against PB, or against the best-segments pace for behind_best.
+}
+
+// ComputeAlerts returns the alerts the latest split of a run triggers. Each
+// alert fires once, when its condition first becomes true, so calling this
+// after every split never repeats an alert for an unchanged situation.
+func ComputeAlerts(att *Attempts, currentSplitsMS []int64, cfg AlertConfig) []Alert {
+	i := len(currentSplitsMS) - 1
+	if i < 0 || currentSplitsMS[i] == 0 {
+		return nil
+	}
+
+	deltas := ComputeSplitDeltas(att, currentSplitsMS, "personal_best")
+	if deltas[i].Skipped {
+		return nil
+	}
+
+	prev := -1
+	for j := i - 1; j >= 0; j-- {
+		if !deltas[j].Skipped {
+			prev = j
+
+			break
+		}
+	}
+
+	pb := att.PersonalBestSplits()
+	hasPB := i < len(pb) && pb[i] != 0
+
+	var alerts []Alert
+
+	if cfg.AheadOfPB && hasPB && deltas[i].IsAhead && (prev < 0 || !deltas[prev].IsAhead) {
+		alerts = append(alerts, Alert{Kind: AlertAheadOfPB, SegmentIndex: i, DeltaMS: deltas[i].DeltaMS})
+	}
+
+	if cfg.PBImpossible {
+		lost, deltaMS := pbOutOfReach(att, currentSplitsMS, i)
+		if lost && (prev < 0 || !wasOutOfReach(att, currentSplitsMS, prev)) {
+			alerts = append(alerts, Alert{Kind: AlertPBImpossible, SegmentIndex: i, DeltaMS: deltaMS})
+		}
+	}
+
+	if cfg.GoldPace && deltas[i].IsBestEver {
+		alerts = append(alerts, Alert{Kind: AlertGoldPace, SegmentIndex: i, DeltaMS: deltas[i].DeltaMS})
+	}
+
+	if cfg.BehindBestMS > 0 {
+		best := att.BestSegmentsCumulative()
+		behind := behindBestMS(best, currentSplitsMS, i)
+
+		if behind >= cfg.BehindBestMS && (prev < 0 || behindBestMS(best, currentSplitsMS, prev) < cfg.BehindBestMS) {
+			alerts = append(alerts, Alert{Kind: AlertBehindBest, SegmentIndex: i, DeltaMS: behind})
+		}
+	}
+
+	return alerts
+}
+
+// pbOutOfReach reports whether the run, having split segment i, would miss PB
+// even with a gold in every remaining segment. The delta is the best possible
+// final time against PB. Unknown golds or no PB leave the PB in reach.
+func pbOutOfReach(att *Attempts, splits []int64, i int) (bool, int64) {
+	pb := att.PersonalBestSplits()
+	if len(pb) == 0 || pb[len(pb)-1] == 0 {
+		return false, 0
+	}
+
+	best := att.BestSegments()
+	possible := splits[i]
+
+	for j := i + 1; j < len(att.Segments); j++ {
+		if j >= len(best) || best[j] == 0 {
+			return false, 0
+		}
+
+		possible += best[j]
+	}
+
+	delta := possible - pb[len(pb)-1]
+
+	return delta > 0, delta
+}
+
+func wasOutOfReach(att *Attempts, splits []int64, i int) bool {
+	lost, _ := pbOutOfReach(att, splits, i)
+
+	return lost
+}
+
+// behindBestMS returns how far split i is behind the best-segments pace, or 0
+// if that pace is unknown there.
+func behindBestMS(bestCumulative, splits []int64, i int) int64 {
+	if i >= len(bestCumulative) || bestCumulative[i] == 0 {
+		return 0
+	}
+
+	return splits[i] - bestCumulative[i]
+}
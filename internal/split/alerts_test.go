@@ -0,0 +1,82 @@
+package split
+
+import "testing"
+
+func hasAlert(alerts []Alert, kind AlertKind) bool {
+	for _, a := range alerts {
+		if a.Kind == kind {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestAheadOfPBFiresOnTransition(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.AddAttempt([]int64{1000, 2000, 3000}, true)
+	att.AddAttempt([]int64{900, 2100, 3200}, true)
+
+	// PB 1000/2000/3000; golds A=900, B=1000, C=1000.
+	cfg := AlertConfig{AheadOfPB: true}
+
+	if a := ComputeAlerts(att, []int64{1100}, cfg); hasAlert(a, AlertAheadOfPB) {
+		t.Fatalf("unexpected alert while behind: %v", a)
+	}
+
+	a := ComputeAlerts(att, []int64{1100, 1950}, cfg)
+	if !hasAlert(a, AlertAheadOfPB) || a[0].DeltaMS != -50 {
+		t.Fatalf("expected ahead of PB alert, got %v", a)
+	}
+
+	// Staying ahead does not repeat the alert.
+	if a := ComputeAlerts(att, []int64{1100, 1950, 2900}, cfg); hasAlert(a, AlertAheadOfPB) {
+		t.Fatalf("alert repeated while still ahead: %v", a)
+	}
+}
+
+func TestPBImpossible(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.AddAttempt([]int64{1000, 2000, 3000}, true)
+	att.AddAttempt([]int64{900, 2100, 3200}, true)
+
+	// PB 1000/2000/3000; golds A=900, B=1000, C=1000.
+	cfg := AlertConfig{PBImpossible: true}
+
+	// 1900 + golds 1000 + 1000 = 3900 > 3000.
+	a := ComputeAlerts(att, []int64{1900}, cfg)
+	if !hasAlert(a, AlertPBImpossible) || a[0].DeltaMS != 900 {
+		t.Fatalf("expected PB impossible alert, got %v", a)
+	}
+
+	if a := ComputeAlerts(att, []int64{1900, 3000}, cfg); hasAlert(a, AlertPBImpossible) {
+		t.Fatalf("alert repeated: %v", a)
+	}
+
+	if a := ComputeAlerts(att, []int64{950}, cfg); len(a) != 0 {
+		t.Fatalf("unexpected alerts: %v", a)
+	}
+}
+
+func TestGoldPaceAndBehindBest(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.AddAttempt([]int64{1000, 2000, 3000}, true)
+	att.AddAttempt([]int64{900, 2100, 3200}, true)
+
+	// PB 1000/2000/3000; golds A=900, B=1000, C=1000.
+	cfg := AlertConfig{GoldPace: true, BehindBestMS: 500}
+
+	if a := ComputeAlerts(att, []int64{850}, cfg); !hasAlert(a, AlertGoldPace) {
+		t.Fatalf("expected gold pace alert, got %v", a)
+	}
+
+	// Best pace at B is 1900; 2500 is 600 behind.
+	a := ComputeAlerts(att, []int64{850, 2500}, cfg)
+	if !hasAlert(a, AlertBehindBest) || hasAlert(a, AlertGoldPace) {
+		t.Fatalf("expected only behind best alert, got %v", a)
+	}
+
+	if a := ComputeAlerts(att, []int64{850, 2500, 0}, cfg); a != nil {
+		t.Fatalf("expected no alerts for a skipped split, got %v", a)
+	}
+}
@@ -1,32 +1,106 @@
 package split
 
-import "time"
+import (
+	"maps"
+	"slices"
+	"strings"
+	"time"
+)
 
 // Segment represents a single segment in a run.
 type Segment struct {
-	Name string `json:"name"`
+	ID    int    `json:"id,omitempty"` // Stable across renames, moves and other segment edits; 0 until assigned.
+	Name  string `json:"name"`
+	Group string `json:"group,omitempty"` // Parent group; consecutive segments with the same group form a subsplit section.
+	Notes string `json:"notes,omitempty"` // Markdown notes shown while the segment is being run.
 }
 
 // Attempt records a single attempt.
 type Attempt struct {
-	ID           int       `json:"id"`
-	StartedAt    time.Time `json:"startedAt"`
-	SplitTimesMS []int64   `json:"splitTimesMs"` // Cumulative split times (0 = skipped).
-	Completed    bool      `json:"completed"`
+	ID           int               `json:"id"`
+	StartedAt    time.Time         `json:"startedAt"`
+	SplitTimesMS []int64           `json:"splitTimesMs"` // Cumulative split times (0 = skipped).
+	Completed    bool              `json:"completed"`
+	EndReason    EndReason         `json:"endReason,omitempty"`
+	EndedSegment int               `json:"endedSegment"` // Index of the segment the run was on when it ended.
+	EndedAt      time.Time         `json:"endedAt"`
+	ElapsedMS    int64             `json:"elapsedMs,omitempty"`    // Timer value when the run ended, including any unsplit segment.
+	PausedMS     int64             `json:"pausedMs,omitempty"`     // Time spent paused during the run.
+	StartSegment int               `json:"startSegment,omitempty"` // Segment a partial-start run began on; earlier splits are a preloaded reference.
+	ExcludeGolds bool              `json:"excludeGolds,omitempty"` // The attempt may not set best segment times.
+	Variables    map[string]string `json:"variables,omitempty"`    // Template variable values, keyed by variable name.
+	Hits         []int             `json:"hits,omitempty"`         // Hits taken per segment, for hit-counted runs.
+	AttemptMetadata
+}
+
+// EndReason records why an attempt ended.
+type EndReason string
+
+const (
+	EndFinished         EndReason = "finished"          // All segments were split.
+	EndReset            EndReason = "reset"             // The runner reset mid-run.
+	EndAbandonedSuspend EndReason = "abandoned_suspend" // A suspended run was discarded.
+	EndCrashRecovered   EndReason = "crash_recovered"   // A run left behind by a crash was discarded.
+	EndManual           EndReason = "manual"            // Entered by hand rather than timed.
+)
+
+// AttemptEnd describes how and when a recorded attempt ended.
+type AttemptEnd struct {
+	Reason    EndReason
+	Segment   int       // Index of the segment the run was on when it ended.
+	StartedAt time.Time // Wall-clock time the run started.
+	EndedAt   time.Time // Wall-clock time the run ended.
+	ElapsedMS int64     // Timer value when the run ended.
+	PausedMS  int64     // Time spent paused during the run.
+
+	StartSegment int   // Segment a partial-start run began on, 0 for full runs.
+	ExcludeGolds bool  // Keep the attempt from setting best segment times.
+	Hits         []int // Hits taken per segment; nil unless the run was hit-counted.
+}
+
+// Reason returns why the attempt ended. Attempts recorded before end reasons
+// existed report finished or reset based on Completed.
+func (at Attempt) Reason() EndReason {
+	if at.EndReason != "" {
+		return at.EndReason
+	}
+
+	if at.Completed {
+		return EndFinished
+	}
+
+	return EndReset
+}
+
+// AttemptMetadata holds user-entered details about an attempt.
+type AttemptMetadata struct {
+	Notes    string            `json:"notes,omitempty"`
+	Tags     []string          `json:"tags,omitempty"`
+	VideoURL string            `json:"videoUrl,omitempty"` // Video URL or local file path.
+	Fields   map[string]string `json:"fields,omitempty"`   // Custom key-value pairs (e.g. platform, game version).
 }
 
 // Attempts tracks category-specific data: segments (snapshotted from a template),
 // PB/best segment data, and attempt history.
 type Attempts struct {
-	ID           string    `json:"id"`
-	TemplateID   string    `json:"templateId"`
-	Name         string    `json:"name"`
-	CategoryName string    `json:"categoryName"`
-	Segments     []Segment `json:"segments"`
-	AttemptCount int       `json:"attemptCount"`
-	History      []Attempt `json:"history"`
-	CreatedAt    time.Time `json:"createdAt"`
-	UpdatedAt    time.Time `json:"updatedAt"`
+	ID             string            `json:"id"`
+	TemplateID     string            `json:"templateId"`
+	Name           string            `json:"name"`
+	CategoryName   string            `json:"categoryName"`
+	Segments       []Segment         `json:"segments"`
+	AttemptCount   int               `json:"attemptCount"`
+	History        []Attempt         `json:"history"`
+	Sessions       []SessionSpan     `json:"sessions,omitempty"`
+	VariableValues map[string]string `json:"variableValues,omitempty"` // Values tagged onto newly recorded attempts.
+	BaselineGolds  []int64           `json:"baselineGolds,omitempty"`  // Best segment times carried over without their attempts.
+	BaselineHits   []int             `json:"baselineHits,omitempty"`   // Fewest hits per segment carried over without their attempts; -1 = none.
+	HitCounting    bool              `json:"hitCounting,omitempty"`    // No-hit category: PB, golds and deltas count hits.
+	Ghosts         []Ghost           `json:"ghosts,omitempty"`         // Other runs to race against, kept apart from History.
+	ActiveGhost    string            `json:"activeGhost,omitempty"`    // ID of the ghost raced alongside the comparison.
+	ResetPolicy    ResetPolicy       `json:"resetPolicy,omitempty"`    // What a reset run contributes by default; empty saves it.
+	LastSegmentID  int               `json:"lastSegmentId,omitempty"`  // Highest segment ID handed out, so removed IDs are never reused.
+	CreatedAt      time.Time         `json:"createdAt"`
+	UpdatedAt      time.Time         `json:"updatedAt"`
 }
 
 // NewAttempts creates a new Attempts with segments snapshotted from segment names.
@@ -38,7 +112,7 @@ func NewAttempts(id, templateID, name, categoryName string, segmentNames []strin
 
 	now := time.Now()
 
-	return &Attempts{
+	att := &Attempts{
 		ID:           id,
 		TemplateID:   templateID,
 		Name:         name,
@@ -47,6 +121,40 @@ func NewAttempts(id, templateID, name, categoryName string, segmentNames []strin
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}
+	att.EnsureSegmentIDs()
+
+	return att
+}
+
+// Clone returns a deep copy of the attempts entry.
+func (a *Attempts) Clone() *Attempts {
+	c := *a
+	c.Segments = slices.Clone(a.Segments)
+	c.Sessions = slices.Clone(a.Sessions)
+	c.VariableValues = maps.Clone(a.VariableValues)
+	c.BaselineGolds = slices.Clone(a.BaselineGolds)
+	c.BaselineHits = slices.Clone(a.BaselineHits)
+	c.Ghosts = slices.Clone(a.Ghosts)
+	c.History = make([]Attempt, len(a.History))
+
+	for i, g := range c.Ghosts {
+		c.Ghosts[i].SplitTimesMS = slices.Clone(g.SplitTimesMS)
+	}
+
+	for i, att := range a.History {
+		att.SplitTimesMS = slices.Clone(att.SplitTimesMS)
+		att.Tags = slices.Clone(att.Tags)
+		att.Fields = maps.Clone(att.Fields)
+		att.Variables = maps.Clone(att.Variables)
+		att.Hits = slices.Clone(att.Hits)
+		c.History[i] = att
+	}
+
+	if a.History == nil {
+		c.History = nil
+	}
+
+	return &c
 }
 
 // SegmentNames returns the names of all segments.
@@ -59,41 +167,94 @@ func (a *Attempts) SegmentNames() []string {
 	return names
 }
 
-// AddAttempt records a new attempt.
+// EnsureSegmentIDs assigns an ID to every segment without one, such as those of
+// entries saved before segments had IDs. IDs are handed out in segment order, so
+// an old entry gets the same IDs each time it is loaded until it is saved.
+func (a *Attempts) EnsureSegmentIDs() {
+	for i := range a.Segments {
+		if a.Segments[i].ID == 0 {
+			a.LastSegmentID++
+			a.Segments[i].ID = a.LastSegmentID
+		}
+	}
+}
+
+// SegmentIndex returns the index of the segment with the given ID, or -1.
+func (a *Attempts) SegmentIndex(id int) int {
+	return slices.IndexFunc(a.Segments, func(s Segment) bool { return s.ID == id })
+}
+
+// AddAttempt records a new attempt that just finished or was reset.
 func (a *Attempts) AddAttempt(splitTimesMS []int64, completed bool) {
+	now := time.Now()
+	end := AttemptEnd{Reason: EndReset, Segment: len(splitTimesMS), StartedAt: now, EndedAt: now}
+
+	if completed {
+		end.Reason = EndFinished
+		end.Segment = max(len(splitTimesMS)-1, 0)
+	}
+
+	a.RecordAttempt(splitTimesMS, completed, end)
+}
+
+// RecordAttempt records a new attempt with an explicit end reason and wall-clock times.
+// The attempt is tagged with the current VariableValues.
+func (a *Attempts) RecordAttempt(splitTimesMS []int64, completed bool, end AttemptEnd) {
 	a.AttemptCount++
 	a.History = append(a.History, Attempt{
 		ID:           a.AttemptCount,
-		StartedAt:    time.Now(),
+		StartedAt:    end.StartedAt,
 		SplitTimesMS: splitTimesMS,
 		Completed:    completed,
+		EndReason:    end.Reason,
+		EndedSegment: end.Segment,
+		EndedAt:      end.EndedAt,
+		ElapsedMS:    end.ElapsedMS,
+		PausedMS:     end.PausedMS,
+		StartSegment: end.StartSegment,
+		ExcludeGolds: end.ExcludeGolds,
+		Variables:    maps.Clone(a.VariableValues),
+		Hits:         end.Hits,
 	})
 	a.UpdatedAt = time.Now()
 }
 
-// PersonalBestSplits returns the split times from the PB attempt, or nil if no PB exists.
-// Attempts with skipped final segments (finalTime == 0) are excluded.
-func (a *Attempts) PersonalBestSplits() []int64 {
-	var bestTime int64
-	var bestSplits []int64
+// AddManualAttempt records a hand-entered attempt. The attempt counts as
+// completed if it covers every segment with a non-zero final split.
+// Returns false if the splits are empty, all skipped, longer than the segment
+// list, or not monotonically increasing.
+func (a *Attempts) AddManualAttempt(splitTimesMS []int64, startedAt time.Time) bool {
+	if len(splitTimesMS) == 0 || len(splitTimesMS) > len(a.Segments) || !increasingSplits(splitTimesMS) {
+		return false
+	}
 
-	for _, att := range a.History {
-		if !att.Completed || len(att.SplitTimesMS) == 0 {
-			continue
-		}
+	last, ok := lastNonZeroBefore(splitTimesMS, len(splitTimesMS))
+	if !ok {
+		return false
+	}
 
-		finalTime := att.SplitTimesMS[len(att.SplitTimesMS)-1]
-		if finalTime == 0 {
-			continue
-		}
+	completed := len(splitTimesMS) == len(a.Segments) && splitTimesMS[len(splitTimesMS)-1] != 0
 
-		if bestSplits == nil || finalTime < bestTime {
-			bestTime = finalTime
-			bestSplits = make([]int64, len(att.SplitTimesMS))
-			copy(bestSplits, att.SplitTimesMS)
-		}
+	a.RecordAttempt(splitTimesMS, completed, AttemptEnd{
+		Reason:    EndManual,
+		Segment:   len(splitTimesMS) - 1,
+		StartedAt: startedAt,
+		EndedAt:   startedAt.Add(time.Duration(last) * time.Millisecond),
+	})
+
+	return true
+}
+
+// PersonalBestSplits returns the split times from the PB attempt, or nil if no PB exists.
+// Attempts with skipped final segments (finalTime == 0) are excluded.
+func (a *Attempts) PersonalBestSplits() []int64 {
+	pb := a.personalBest()
+	if pb == nil {
+		return nil
 	}
 
+	bestSplits := slices.Clone(pb.SplitTimesMS)
+
 	// Normalize effectively-skipped segments: if a cumulative equals the
 	// previous non-zero cumulative, the segment was collapsed/skipped.
 	for i := 1; i < len(bestSplits); i++ {
@@ -109,27 +270,47 @@ func (a *Attempts) PersonalBestSplits() []int64 {
 	return bestSplits
 }
 
-// BestSegments returns the best individual segment time for each segment across all attempts.
-// Includes incomplete runs. Returns a slice where 0 means no data for that segment.
+// personalBest returns the completed attempt with the lowest final time, or nil.
+// Partial-start runs never count.
+func (a *Attempts) personalBest() *Attempt {
+	var pb *Attempt
+
+	for i, att := range a.History {
+		if !att.Completed || len(att.SplitTimesMS) == 0 || att.IsPartialStart() {
+			continue
+		}
+
+		finalTime := att.SplitTimesMS[len(att.SplitTimesMS)-1]
+		if finalTime == 0 {
+			continue
+		}
+
+		if pb == nil || finalTime < pb.SplitTimesMS[len(pb.SplitTimesMS)-1] {
+			pb = &a.History[i]
+		}
+	}
+
+	return pb
+}
+
+// BestSegments returns the best individual segment time for each segment across all attempts
+// and BaselineGolds. Includes incomplete runs. Returns a slice where 0 means no data for that segment.
 func (a *Attempts) BestSegments() []int64 {
 	best := make([]int64, len(a.Segments))
+	copy(best, a.BaselineGolds)
 
 	for _, att := range a.History {
-		for i, splitMS := range att.SplitTimesMS {
-			if splitMS == 0 || i >= len(a.Segments) {
-				continue
-			}
+		if att.ExcludeGolds {
+			continue
+		}
 
-			var segTime int64
-			if i == 0 {
-				segTime = splitMS
-			} else if prev, ok := lastNonZeroBefore(att.SplitTimesMS, i); ok {
-				segTime = splitMS - prev
-			} else {
+		for i := range att.SplitTimesMS {
+			if i >= len(a.Segments) {
 				continue
 			}
 
-			if segTime <= 0 {
+			segTime, ok := att.SegmentTimeMS(i)
+			if !ok {
 				continue
 			}
 
@@ -142,6 +323,40 @@ func (a *Attempts) BestSegments() []int64 {
 	return best
 }
 
+// IsPartialStart reports whether the run began after the first segment.
+func (at Attempt) IsPartialStart() bool {
+	return at.StartSegment > 0
+}
+
+// SegmentTimeMS returns the time the attempt actually spent in segment i.
+// Segments before a partial start were preloaded, not run, and report false.
+func (at Attempt) SegmentTimeMS(i int) (int64, bool) {
+	if i < at.StartSegment {
+		return 0, false
+	}
+
+	return segmentTimeMS(at.SplitTimesMS, i)
+}
+
+// segmentTimeMS returns the individual time of segment i from cumulative splits.
+// Returns false if the segment was skipped or no earlier split exists to measure from.
+func segmentTimeMS(splits []int64, i int) (int64, bool) {
+	if splits[i] == 0 {
+		return 0, false
+	}
+
+	if i == 0 {
+		return splits[0], true
+	}
+
+	prev, ok := lastNonZeroBefore(splits, i)
+	if !ok || splits[i]-prev <= 0 {
+		return 0, false
+	}
+
+	return splits[i] - prev, true
+}
+
 // BestSegmentsCumulative returns cumulative splits built from each segment's best time.
 // Computed from all history (including incomplete runs).
 // Returns partial data: cumulative values up to the first segment with no best,
@@ -176,7 +391,7 @@ func (a *Attempts) AverageSplits() []int64 {
 
 	for _, att := range a.History {
 		for i, t := range att.SplitTimesMS {
-			if t == 0 || i >= n {
+			if t == 0 || i >= n || i < att.StartSegment {
 				continue
 			}
 
@@ -387,10 +602,21 @@ func (a *Attempts) EditAttemptSplits(attemptID int, newSplits []int64) bool {
 		return false
 	}
 
-	// Validate monotonically increasing for non-zero values.
+	if !increasingSplits(newSplits) {
+		return false
+	}
+
+	target.SplitTimesMS = newSplits
+	a.UpdatedAt = time.Now()
+
+	return true
+}
+
+// increasingSplits reports whether the non-zero cumulative splits are strictly increasing.
+func increasingSplits(splits []int64) bool {
 	var lastNonZero int64
 
-	for _, v := range newSplits {
+	for _, v := range splits {
 		if v == 0 {
 			continue
 		}
@@ -402,8 +628,123 @@ func (a *Attempts) EditAttemptSplits(attemptID int, newSplits []int64) bool {
 		lastNonZero = v
 	}
 
-	target.SplitTimesMS = newSplits
+	return true
+}
+
+// EditAttemptMetadata replaces the notes, tags, video link and custom fields of an attempt.
+// Tags are trimmed and de-duplicated; blank tags and field keys are dropped.
+// Returns false if the attempt is not found.
+func (a *Attempts) EditAttemptMetadata(attemptID int, meta AttemptMetadata) bool {
+	target := a.findAttempt(attemptID)
+	if target == nil {
+		return false
+	}
+
+	var tags []string
+	seen := make(map[string]bool)
+
+	for _, tag := range meta.Tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[tag] {
+			continue
+		}
+
+		seen[tag] = true
+		tags = append(tags, tag)
+	}
+
+	var fields map[string]string
+
+	for k, v := range meta.Fields {
+		k = strings.TrimSpace(k)
+		if k == "" {
+			continue
+		}
+
+		if fields == nil {
+			fields = make(map[string]string)
+		}
+
+		fields[k] = strings.TrimSpace(v)
+	}
+
+	target.AttemptMetadata = AttemptMetadata{
+		Notes:    meta.Notes,
+		Tags:     tags,
+		VideoURL: strings.TrimSpace(meta.VideoURL),
+		Fields:   fields,
+	}
 	a.UpdatedAt = time.Now()
 
 	return true
 }
+
+// HasTag reports whether the attempt is tagged with tag.
+func (at Attempt) HasTag(tag string) bool {
+	return slices.Contains(at.Tags, tag)
+}
+
+// HistoryWithTag returns the attempts tagged with tag, oldest first.
+func (a *Attempts) HistoryWithTag(tag string) []Attempt {
+	return a.FilterHistory(HistoryFilter{Tag: tag})
+}
+
+// Tags returns every distinct tag used in History, sorted.
+func (a *Attempts) Tags() []string {
+	var tags []string
+
+	for _, att := range a.History {
+		for _, tag := range att.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+
+	slices.Sort(tags)
+
+	return tags
+}
+
+func (a *Attempts) findAttempt(attemptID int) *Attempt {
+	for i := range a.History {
+		if a.History[i].ID == attemptID {
+			return &a.History[i]
+		}
+	}
+
+	return nil
+}
+
+// SetSegmentNotes sets the markdown notes of the segment at index.
+func (a *Attempts) SetSegmentNotes(index int, notes string) bool {
+	if index < 0 || index >= len(a.Segments) {
+		return false
+	}
+
+	a.Segments[index].Notes = notes
+	a.UpdatedAt = time.Now()
+
+	return true
+}
+
+// SegmentNotes returns the notes for each segment, falling back to the template's
+// notes for segments without their own. The template is ignored if its segment
+// list no longer matches.
+func (a *Attempts) SegmentNotes(tmpl *Template) []string {
+	notes := make([]string, len(a.Segments))
+
+	var fallback []string
+	if tmpl != nil && slices.Equal(tmpl.SegmentNames, a.SegmentNames()) {
+		fallback = tmpl.SegmentNotes
+	}
+
+	for i, seg := range a.Segments {
+		notes[i] = seg.Notes
+		if notes[i] == "" && i < len(fallback) {
+			notes[i] = fallback[i]
+		}
+	}
+
+	return notes
+}
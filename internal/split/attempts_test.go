@@ -1,6 +1,9 @@
 package split
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestNewAttempts(t *testing.T) {
 	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"Seg1", "Seg2"})
@@ -552,3 +555,237 @@ func TestEditAttemptSplitsAcceptsSkips(t *testing.T) {
 		t.Fatalf("expected 0, got %d", att.History[0].SplitTimesMS[1])
 	}
 }
+
+func TestEditAttemptMetadata(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+	att.AddAttempt([]int64{1000, 2000}, true)
+
+	ok := att.EditAttemptMetadata(1, AttemptMetadata{
+		Notes:    "Clean run",
+		Tags:     []string{" pb ", "", "pb", "stream"},
+		VideoURL: " https://example.com/v/1 ",
+		Fields:   map[string]string{"platform": "PC", " ": "dropped"},
+	})
+	if !ok {
+		t.Fatal("expected edit to succeed")
+	}
+
+	got := att.History[0]
+	if got.Notes != "Clean run" {
+		t.Fatalf("expected notes to be saved, got %q", got.Notes)
+	}
+
+	if len(got.Tags) != 2 || got.Tags[0] != "pb" || got.Tags[1] != "stream" {
+		t.Fatalf("expected tags [pb stream], got %v", got.Tags)
+	}
+
+	if got.VideoURL != "https://example.com/v/1" {
+		t.Fatalf("expected trimmed video URL, got %q", got.VideoURL)
+	}
+
+	if len(got.Fields) != 1 || got.Fields["platform"] != "PC" {
+		t.Fatalf("expected fields {platform: PC}, got %v", got.Fields)
+	}
+
+	if att.EditAttemptMetadata(99, AttemptMetadata{}) {
+		t.Fatal("expected edit of nonexistent attempt to fail")
+	}
+}
+
+func TestHistoryWithTag(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A"})
+	att.AddAttempt([]int64{1000}, true)
+	att.AddAttempt([]int64{900}, true)
+	att.AddAttempt([]int64{950}, true)
+
+	att.EditAttemptMetadata(1, AttemptMetadata{Tags: []string{"race"}})
+	att.EditAttemptMetadata(3, AttemptMetadata{Tags: []string{"race", "practice"}})
+
+	tagged := att.HistoryWithTag("race")
+	if len(tagged) != 2 || tagged[0].ID != 1 || tagged[1].ID != 3 {
+		t.Fatalf("expected attempts 1 and 3, got %v", tagged)
+	}
+
+	if len(att.HistoryWithTag("missing")) != 0 {
+		t.Fatal("expected no attempts for unused tag")
+	}
+
+	tags := att.Tags()
+	if len(tags) != 2 || tags[0] != "practice" || tags[1] != "race" {
+		t.Fatalf("expected sorted tags [practice race], got %v", tags)
+	}
+}
+
+func TestAttemptReason(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+	att.AddAttempt([]int64{1000}, false)
+	att.AddAttempt([]int64{1000, 2000}, true)
+
+	if got := att.History[0].Reason(); got != EndReset {
+		t.Fatalf("expected reset, got %s", got)
+	}
+
+	if att.History[0].EndedSegment != 1 {
+		t.Fatalf("expected reset run to end on segment 1, got %d", att.History[0].EndedSegment)
+	}
+
+	if got := att.History[1].Reason(); got != EndFinished {
+		t.Fatalf("expected finished, got %s", got)
+	}
+
+	if att.History[1].EndedSegment != 1 {
+		t.Fatalf("expected finished run to end on segment 1, got %d", att.History[1].EndedSegment)
+	}
+
+	// Attempts saved before end reasons existed derive one from Completed.
+	legacy := Attempt{Completed: true}
+	if legacy.Reason() != EndFinished {
+		t.Fatalf("expected legacy completed attempt to read as finished, got %s", legacy.Reason())
+	}
+}
+
+func TestRecordAttempt(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	end := start.Add(90 * time.Second)
+
+	att.RecordAttempt([]int64{1000}, false, AttemptEnd{
+		Reason:    EndCrashRecovered,
+		Segment:   1,
+		StartedAt: start,
+		EndedAt:   end,
+	})
+
+	got := att.History[0]
+	if got.Reason() != EndCrashRecovered || got.EndedSegment != 1 {
+		t.Fatalf("expected crash-recovered on segment 1, got %s on %d", got.Reason(), got.EndedSegment)
+	}
+
+	if !got.StartedAt.Equal(start) || !got.EndedAt.Equal(end) {
+		t.Fatalf("expected wall-clock times to be kept, got %v - %v", got.StartedAt, got.EndedAt)
+	}
+
+	if att.AttemptCount != 1 {
+		t.Fatalf("expected attempt count 1, got %d", att.AttemptCount)
+	}
+}
+
+func TestAddManualAttempt(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if !att.AddManualAttempt([]int64{1000, 2500}, start) {
+		t.Fatal("expected manual attempt to be accepted")
+	}
+
+	got := att.History[0]
+	if !got.Completed || got.Reason() != EndManual {
+		t.Fatalf("expected completed manual attempt, got %+v", got)
+	}
+
+	if !got.EndedAt.Equal(start.Add(2500 * time.Millisecond)) {
+		t.Fatalf("expected end time from final split, got %v", got.EndedAt)
+	}
+
+	if !att.AddManualAttempt([]int64{1000}, start) || att.History[1].Completed {
+		t.Fatal("expected partial manual attempt to be accepted as incomplete")
+	}
+
+	if att.AddManualAttempt([]int64{2000, 1000}, start) {
+		t.Fatal("expected non-monotonic splits to be rejected")
+	}
+
+	if att.AddManualAttempt([]int64{1000, 2000, 3000}, start) {
+		t.Fatal("expected splits longer than the segment list to be rejected")
+	}
+
+	if att.AddManualAttempt([]int64{0, 0}, start) {
+		t.Fatal("expected all-skipped splits to be rejected")
+	}
+}
+
+func TestAttemptsClone(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+	att.AddAttempt([]int64{1000, 2000}, true)
+	att.EditAttemptMetadata(1, AttemptMetadata{Tags: []string{"pb"}, Fields: map[string]string{"k": "v"}})
+
+	c := att.Clone()
+	c.Segments[0].Name = "Changed"
+	c.History[0].SplitTimesMS[0] = 1
+	c.History[0].Tags[0] = "changed"
+	c.History[0].Fields["k"] = "changed"
+
+	if att.Segments[0].Name != "A" || att.History[0].SplitTimesMS[0] != 1000 {
+		t.Fatal("expected clone edits not to affect the original")
+	}
+
+	if att.History[0].Tags[0] != "pb" || att.History[0].Fields["k"] != "v" {
+		t.Fatal("expected clone metadata edits not to affect the original")
+	}
+}
+
+func TestSegmentNotesFallBackToTemplate(t *testing.T) {
+	tmpl := NewTemplate("t-1", "Game", []string{"A", "B"})
+	tmpl.SetSegmentNotes(0, "template A")
+	tmpl.SetSegmentNotes(1, "template B")
+
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+	if !att.SetSegmentNotes(1, "category B") {
+		t.Fatal("expected notes to be set")
+	}
+
+	notes := att.SegmentNotes(tmpl)
+	if notes[0] != "template A" || notes[1] != "category B" {
+		t.Fatalf("unexpected notes: %v", notes)
+	}
+
+	// A template with a different layout is ignored.
+	tmpl.SetSegmentNames([]string{"A", "X", "B"})
+
+	if notes := att.SegmentNotes(tmpl); notes[0] != "" {
+		t.Fatalf("expected out-of-sync template to be ignored, got %v", notes)
+	}
+}
+
+func TestPartialStartAttempts(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.RecordAttempt([]int64{1000, 2000, 3000}, true, AttemptEnd{Reason: EndFinished, Segment: 2})
+
+	// Preloaded A and B from the PB; a fast C is the only segment actually run.
+	att.RecordAttempt([]int64{1000, 2000, 2500}, true, AttemptEnd{Reason: EndFinished, Segment: 2, StartSegment: 2})
+
+	if !att.History[1].IsPartialStart() {
+		t.Fatal("expected partial start")
+	}
+
+	if pb := att.PersonalBestSplits(); pb[2] != 3000 {
+		t.Fatalf("partial start must not become the PB, got %v", pb)
+	}
+
+	best := att.BestSegments()
+	if best[0] != 1000 || best[1] != 1000 || best[2] != 500 {
+		t.Fatalf("unexpected golds: %v", best)
+	}
+
+	att.RecordAttempt([]int64{1000, 2000, 2100}, true, AttemptEnd{Reason: EndFinished, Segment: 2, StartSegment: 2, ExcludeGolds: true})
+
+	if best := att.BestSegments(); best[2] != 500 {
+		t.Fatalf("ExcludeGolds attempt changed golds: %v", best)
+	}
+}
+
+func TestEnsureSegmentIDs(t *testing.T) {
+	// Saved before segments had IDs.
+	att := &Attempts{Segments: []Segment{{Name: "A"}, {Name: "B"}}}
+	att.EnsureSegmentIDs()
+
+	if att.Segments[0].ID != 1 || att.Segments[1].ID != 2 || att.LastSegmentID != 2 {
+		t.Fatalf("unexpected IDs: %+v, last %d", att.Segments, att.LastSegmentID)
+	}
+
+	att.InsertSegment(0, "Intro")
+
+	if att.Segments[0].ID != 3 || att.SegmentIndex(2) != 2 {
+		t.Fatalf("expected a new ID for the inserted segment, got %+v", att.Segments)
+	}
+}
@@ -3,6 +3,7 @@ package split
 // Delta represents the time difference for a segment compared to a reference.
 type Delta struct {
 	SegmentIndex int   `json:"segmentIndex"`
+	GroupIndex   int   `json:"groupIndex"` // Index into Attempts.Groups, or -1 if the segment is ungrouped.
 	DeltaMS      int64 `json:"deltaMs"`    // Cumulative delta. Positive = behind, negative = ahead.
 	IsBestEver   bool  `json:"isBestEver"` // True if this is the best segment time ever.
 	IsAhead      bool  `json:"isAhead"`    // True if ahead of comparison.
@@ -17,11 +18,17 @@ func ComputeDelta(currentSplitMS, comparisonSplitMS int64) int64 {
 
 // ComparisonSplits returns the reference splits for the given comparison type.
 // For non-PB comparisons, gaps (0 values) are filled from PB when available.
+// A ghost comparison ("ghost:<id>") is returned as imported, since mixing in
+// the runner's own PB would no longer be the ghost's run.
 func ComparisonSplits(att *Attempts, comparison string) []int64 {
 	if comparison == "personal_best" || comparison == "" {
 		return att.PersonalBestSplits()
 	}
 
+	if splits, ok := ghostSplits(att, comparison); ok {
+		return splits
+	}
+
 	var splits []int64
 
 	switch comparison {
@@ -64,10 +71,11 @@ func ComparisonSplits(att *Attempts, comparison string) []int64 {
 func ComputeSplitDeltas(att *Attempts, currentSplitsMS []int64, comparison string) []Delta {
 	compSplits := ComparisonSplits(att, comparison)
 	bestSegs := att.BestSegments()
+	groupOf := groupIndexOf(att.Groups(), len(currentSplitsMS))
 	deltas := make([]Delta, len(currentSplitsMS))
 
 	for i, splitMS := range currentSplitsMS {
-		d := Delta{SegmentIndex: i}
+		d := Delta{SegmentIndex: i, GroupIndex: groupOf[i]}
 
 		if splitMS == 0 {
 			d.Skipped = true
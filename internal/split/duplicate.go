@@ -0,0 +1,101 @@
+package split
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// CopyMode selects how much history a duplicated attempts entry keeps.
+type CopyMode string
+
+const (
+	CopyHistory CopyMode = "history" // Every attempt and session.
+	CopyNone    CopyMode = "none"    // Segments only, starting from scratch.
+	CopyGolds   CopyMode = "golds"   // Best segment times as BaselineGolds, without attempts.
+	CopyPB      CopyMode = "pb"      // Only the personal best attempt.
+)
+
+// Duplicate copies the template under a new ID and name.
+func (t *Template) Duplicate(id, name string) *Template {
+	c := *t
+	c.ID = id
+	c.Name = name
+	c.SegmentNames = slices.Clone(t.SegmentNames)
+	c.SegmentGroups = slices.Clone(t.SegmentGroups)
+	c.SegmentNotes = slices.Clone(t.SegmentNotes)
+	c.SegmentIcons = slices.Clone(t.SegmentIcons)
+	c.Variables = slices.Clone(t.Variables)
+	c.Legs = slices.Clone(t.Legs)
+	c.Game.ExternalIDs = maps.Clone(t.Game.ExternalIDs)
+
+	for i, v := range c.Variables {
+		c.Variables[i].Values = slices.Clone(v.Values)
+	}
+
+	now := time.Now()
+	c.CreatedAt = now
+	c.UpdatedAt = now
+
+	return &c
+}
+
+// Duplicate copies the attempts entry under a new ID, linked to templateID.
+// Segments, groups, notes and variable values are always kept; mode decides
+// which history comes along. Returns nil for an unknown mode.
+func (a *Attempts) Duplicate(id, templateID, name, categoryName string, mode CopyMode) *Attempts {
+	c := a.Clone()
+	c.ID = id
+	c.TemplateID = templateID
+	c.Name = name
+	c.CategoryName = categoryName
+
+	switch mode {
+	case CopyHistory:
+	case CopyNone:
+		c.clearHistory()
+		c.BaselineGolds = nil
+		c.BaselineHits = nil
+	case CopyGolds:
+		c.clearHistory()
+		c.BaselineGolds = a.BestSegments()
+		c.BaselineHits = a.BestSegmentHits()
+
+		if !slices.ContainsFunc(c.BaselineGolds, func(ms int64) bool { return ms != 0 }) {
+			c.BaselineGolds = nil
+		}
+
+		if !slices.ContainsFunc(c.BaselineHits, func(h int) bool { return h >= 0 }) {
+			c.BaselineHits = nil
+		}
+	case CopyPB:
+		pb := a.personalBest()
+		c.clearHistory()
+
+		if pb != nil {
+			at := *pb
+			at.ID = 1
+			at.SplitTimesMS = slices.Clone(pb.SplitTimesMS)
+			at.Tags = slices.Clone(pb.Tags)
+			at.Fields = maps.Clone(pb.Fields)
+			at.Variables = maps.Clone(pb.Variables)
+			at.Hits = slices.Clone(pb.Hits)
+			c.History = []Attempt{at}
+			c.AttemptCount = 1
+		}
+	default:
+		return nil
+	}
+
+	now := time.Now()
+	c.CreatedAt = now
+	c.UpdatedAt = now
+
+	return c
+}
+
+func (a *Attempts) clearHistory() {
+	a.History = nil
+	a.Sessions = nil
+	a.AttemptCount = 0
+}
@@ -0,0 +1,110 @@
+package split
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDuplicateAttemptsHistory(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+	att.AddAttempt([]int64{1000, 3000}, true)
+	att.AddAttempt([]int64{900, 3200}, true)
+	att.AddAttempt([]int64{1100}, false)
+
+	c := att.Duplicate("a-2", "t-2", "Copy", "No Major Glitches", CopyHistory)
+	if c.ID != "a-2" || c.TemplateID != "t-2" || c.CategoryName != "No Major Glitches" {
+		t.Fatalf("unexpected copy identity: %+v", c)
+	}
+
+	if len(c.History) != 3 || c.AttemptCount != 3 {
+		t.Fatalf("expected full history, got %d attempts", len(c.History))
+	}
+
+	c.History[0].SplitTimesMS[0] = 1
+
+	if att.History[0].SplitTimesMS[0] != 1000 {
+		t.Fatal("expected the copy not to share history with the original")
+	}
+}
+
+func TestDuplicateAttemptsGoldsOnly(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+	att.AddAttempt([]int64{1000, 3000}, true)
+	att.AddAttempt([]int64{900, 3200}, true)
+	att.AddAttempt([]int64{1100}, false)
+
+	c := att.Duplicate("a-2", "t-1", "", "Any%", CopyGolds)
+	if len(c.History) != 0 || c.AttemptCount != 0 {
+		t.Fatalf("expected no history, got %d attempts", len(c.History))
+	}
+
+	if best := c.BestSegments(); !slices.Equal(best, []int64{900, 2000}) {
+		t.Fatalf("expected golds to carry over, got %v", best)
+	}
+
+	if c.PersonalBestSplits() != nil {
+		t.Fatal("expected no PB")
+	}
+
+	// A new attempt only beats a baseline gold when it is faster.
+	c.AddAttempt([]int64{950, 2900}, true)
+
+	if best := c.BestSegments(); !slices.Equal(best, []int64{900, 1950}) {
+		t.Fatalf("unexpected golds after a new attempt: %v", best)
+	}
+}
+
+func TestDuplicateAttemptsPBOnly(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+	att.AddAttempt([]int64{1000, 3000}, true)
+	att.AddAttempt([]int64{900, 3200}, true)
+	att.AddAttempt([]int64{1100}, false)
+
+	c := att.Duplicate("a-2", "t-1", "", "Any%", CopyPB)
+	if len(c.History) != 1 || c.History[0].ID != 1 || c.AttemptCount != 1 {
+		t.Fatalf("expected only the PB, got %+v", c.History)
+	}
+
+	if pb := c.PersonalBestSplits(); !slices.Equal(pb, []int64{1000, 3000}) {
+		t.Fatalf("unexpected PB: %v", pb)
+	}
+}
+
+func TestDuplicateAttemptsUnknownMode(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+
+	if att.Duplicate("a-2", "t-1", "", "", CopyMode("bogus")) != nil {
+		t.Fatal("expected unknown mode to fail")
+	}
+}
+
+func TestRemapBaselineGolds(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.BaselineGolds = []int64{900, 2000, 3000}
+
+	if !att.RemapSegments([]string{"C", "AB"}, [][]int{{2}, {0, 1}}) {
+		t.Fatal("expected remap to succeed")
+	}
+
+	// The merged segment was never run as one, so its baseline gold is unknown.
+	if !slices.Equal(att.BaselineGolds, []int64{3000, 0}) {
+		t.Fatalf("unexpected baseline golds: %v", att.BaselineGolds)
+	}
+}
+
+func TestDuplicateTemplate(t *testing.T) {
+	tmpl := NewTemplate("t-1", "Game", []string{"A", "B"})
+	tmpl.SetSegmentNotes(0, "note")
+
+	c := tmpl.Duplicate("t-2", "Game (copy)")
+	if c.ID != "t-2" || c.Name != "Game (copy)" {
+		t.Fatalf("unexpected copy: %+v", c)
+	}
+
+	c.SegmentNames[0] = "X"
+	c.SegmentNotes[0] = "changed"
+
+	if tmpl.SegmentNames[0] != "A" || tmpl.SegmentNotes[0] != "note" {
+		t.Fatal("expected the copy not to share slices with the original")
+	}
+}
@@ -0,0 +1,124 @@
+package split
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// GhostComparisonPrefix selects a ghost in ComparisonSplits: "ghost:<id>".
+const GhostComparisonPrefix = "ghost:"
+
+// Ghost is someone else's run, such as the world record, kept as a comparison.
+// Ghosts never count towards the category's own PB or golds.
+type Ghost struct {
+	ID           string    `json:"id"`
+	Name         string    `json:"name"`             // e.g. the runner's name or "WR".
+	Source       string    `json:"source,omitempty"` // Where the splits came from, such as a URL or file name.
+	SplitTimesMS []int64   `json:"splitTimesMs"`     // Cumulative split times (0 = unknown).
+	AddedAt      time.Time `json:"addedAt"`
+}
+
+// GhostComparison returns the comparison name that races the ghost with the given ID.
+func GhostComparison(id string) string {
+	return GhostComparisonPrefix + id
+}
+
+// AddGhost adds a ghost, replacing one with the same ID. Returns false if the
+// splits are empty, longer than the segment list, or not increasing.
+func (a *Attempts) AddGhost(g Ghost) bool {
+	if len(g.SplitTimesMS) == 0 || len(g.SplitTimesMS) > len(a.Segments) || !increasingSplits(g.SplitTimesMS) {
+		return false
+	}
+
+	a.Ghosts = slices.DeleteFunc(a.Ghosts, func(other Ghost) bool { return other.ID == g.ID })
+	a.Ghosts = append(a.Ghosts, g)
+	a.UpdatedAt = time.Now()
+
+	return true
+}
+
+// RemoveGhost deletes a ghost. Returns false if it does not exist. The active
+// ghost is cleared if it was the one removed.
+func (a *Attempts) RemoveGhost(id string) bool {
+	n := len(a.Ghosts)
+
+	a.Ghosts = slices.DeleteFunc(a.Ghosts, func(g Ghost) bool { return g.ID == id })
+	if len(a.Ghosts) == n {
+		return false
+	}
+
+	if a.ActiveGhost == id {
+		a.ActiveGhost = ""
+	}
+
+	a.UpdatedAt = time.Now()
+
+	return true
+}
+
+// Ghost returns the ghost with the given ID, or nil.
+func (a *Attempts) Ghost(id string) *Ghost {
+	i := slices.IndexFunc(a.Ghosts, func(g Ghost) bool { return g.ID == id })
+	if i < 0 {
+		return nil
+	}
+
+	return &a.Ghosts[i]
+}
+
+// SetActiveGhost picks the ghost raced alongside the normal comparison.
+// An empty ID stops racing. Returns false if the ghost does not exist.
+func (a *Attempts) SetActiveGhost(id string) bool {
+	if id != "" && a.Ghost(id) == nil {
+		return false
+	}
+
+	a.ActiveGhost = id
+	a.UpdatedAt = time.Now()
+
+	return true
+}
+
+// GhostFrom turns the PB of another attempts entry, such as an imported
+// category, into a ghost for this one. Segments are matched by name; segments
+// without a match get an unknown split. Returns false if other has no PB or no
+// segment matched.
+func (a *Attempts) GhostFrom(other *Attempts, id, name, source string) (Ghost, bool) {
+	pb := other.PersonalBestSplits()
+	if pb == nil {
+		return Ghost{}, false
+	}
+
+	sources := SuggestSegmentSources(other.SegmentNames(), a.SegmentNames())
+	splits := make([]int64, len(a.Segments))
+	matched := false
+
+	for j, src := range sources {
+		if len(src) == 1 && src[0] < len(pb) && pb[src[0]] != 0 {
+			splits[j] = pb[src[0]]
+			matched = true
+		}
+	}
+
+	if !matched || !increasingSplits(splits) {
+		return Ghost{}, false
+	}
+
+	return Ghost{ID: id, Name: name, Source: source, SplitTimesMS: splits, AddedAt: time.Now()}, true
+}
+
+// ghostSplits returns the splits of the ghost a "ghost:<id>" comparison names.
+func ghostSplits(att *Attempts, comparison string) ([]int64, bool) {
+	id, ok := strings.CutPrefix(comparison, GhostComparisonPrefix)
+	if !ok {
+		return nil, false
+	}
+
+	g := att.Ghost(id)
+	if g == nil {
+		return nil, false
+	}
+
+	return slices.Clone(g.SplitTimesMS), true
+}
@@ -0,0 +1,84 @@
+package split
+
+import "testing"
+
+func TestGhostComparison(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.AddAttempt([]int64{1000, 2000, 3000}, true)
+
+	if !att.AddGhost(Ghost{ID: "wr", Name: "WR", SplitTimesMS: []int64{900, 0, 2500}}) {
+		t.Fatal("expected ghost to be added")
+	}
+
+	if att.AddGhost(Ghost{ID: "bad", SplitTimesMS: []int64{900, 800}}) {
+		t.Fatal("expected decreasing ghost splits to be rejected")
+	}
+
+	// Ghost gaps stay unknown rather than being filled from the runner's PB.
+	comp := ComparisonSplits(att, GhostComparison("wr"))
+	if len(comp) != 3 || comp[0] != 900 || comp[1] != 0 || comp[2] != 2500 {
+		t.Fatalf("unexpected ghost comparison: %v", comp)
+	}
+
+	d := ComputeSplitDeltas(att, []int64{950}, GhostComparison("wr"))
+	if d[0].DeltaMS != 50 || d[0].IsAhead {
+		t.Fatalf("unexpected delta against ghost: %+v", d[0])
+	}
+
+	if pb := att.PersonalBestSplits(); pb[2] != 3000 {
+		t.Fatalf("ghost must not affect PB, got %v", pb)
+	}
+
+	if comp := ComparisonSplits(att, GhostComparison("missing")); comp[2] != 3000 {
+		t.Fatalf("expected unknown ghost to fall back to PB, got %v", comp)
+	}
+}
+
+func TestRemoveGhostClearsActive(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A"})
+	att.AddGhost(Ghost{ID: "wr", SplitTimesMS: []int64{900}})
+
+	if att.SetActiveGhost("missing") {
+		t.Fatal("expected unknown ghost to be rejected")
+	}
+
+	att.SetActiveGhost("wr")
+
+	if !att.RemoveGhost("wr") || att.ActiveGhost != "" {
+		t.Fatalf("expected ghost removed and deactivated, active=%q", att.ActiveGhost)
+	}
+
+	if att.RemoveGhost("wr") {
+		t.Fatal("expected second remove to fail")
+	}
+}
+
+func TestGhostFrom(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "New", "C"})
+
+	other := NewAttempts("o-1", "t-2", "", "Any%", []string{"A", "B", "C"})
+	if _, ok := att.GhostFrom(other, "g", "WR", ""); ok {
+		t.Fatal("expected no ghost without a PB")
+	}
+
+	other.AddAttempt([]int64{800, 1700, 2400}, true)
+
+	g, ok := att.GhostFrom(other, "g", "WR", "wr.json")
+	if !ok {
+		t.Fatal("expected ghost from PB")
+	}
+
+	if s := g.SplitTimesMS; s[0] != 800 || s[1] != 0 || s[2] != 2400 {
+		t.Fatalf("unexpected ghost splits: %v", s)
+	}
+}
+
+func TestRemapGhosts(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.AddGhost(Ghost{ID: "wr", SplitTimesMS: []int64{900, 1800, 2500}})
+	att.RemapSegments([]string{"AB", "C"}, [][]int{{0, 1}, {2}})
+
+	if s := att.Ghost("wr").SplitTimesMS; len(s) != 2 || s[0] != 1800 || s[1] != 2500 {
+		t.Fatalf("unexpected remapped ghost: %v", s)
+	}
+}
@@ -0,0 +1,181 @@
+package split
+
+import "time"
+
+// SegmentGroup is a run of consecutive segments sharing the same Group name,
+// such as the levels of one world. Start and End are inclusive segment indices.
+type SegmentGroup struct {
+	Name  string `json:"name"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// GroupSummary holds group-level times derived from the group's segments.
+type GroupSummary struct {
+	SegmentGroup
+	PersonalBestMS        int64 `json:"personalBestMs"`        // PB cumulative at the group's last segment.
+	PersonalBestSegmentMS int64 `json:"personalBestSegmentMs"` // Time the PB spent in the group.
+	BestSegmentMS         int64 `json:"bestSegmentMs"`         // Sum of the segments' golds, 0 if any is missing.
+}
+
+// GroupDelta is the delta for a finished group against the comparison.
+type GroupDelta struct {
+	GroupIndex int   `json:"groupIndex"`
+	DeltaMS    int64 `json:"deltaMs"`    // Cumulative delta at the group's last segment.
+	IsBestEver bool  `json:"isBestEver"` // True if the group beat the sum of its golds.
+	IsAhead    bool  `json:"isAhead"`
+	GainedTime bool  `json:"gainedTime"` // True if the group took less time than in the comparison.
+	Skipped    bool  `json:"skipped"`    // True if the group's time could not be measured.
+	Collapsed  bool  `json:"collapsed"`  // True if the timer shows the group as a single row.
+}
+
+// SetSegmentGroups assigns each segment to a group by name. An empty name leaves
+// the segment ungrouped, and nil clears all groups. Returns false if groups is
+// non-nil and its length differs from the segment count.
+func (a *Attempts) SetSegmentGroups(groups []string) bool {
+	if groups != nil && len(groups) != len(a.Segments) {
+		return false
+	}
+
+	for i := range a.Segments {
+		a.Segments[i].Group = ""
+		if groups != nil {
+			a.Segments[i].Group = groups[i]
+		}
+	}
+
+	a.UpdatedAt = time.Now()
+
+	return true
+}
+
+// Groups returns the segment groups in order. Ungrouped segments are not included.
+func (a *Attempts) Groups() []SegmentGroup {
+	var groups []SegmentGroup
+
+	for i, seg := range a.Segments {
+		if seg.Group == "" {
+			continue
+		}
+
+		if n := len(groups); n > 0 && groups[n-1].Name == seg.Group && groups[n-1].End == i-1 {
+			groups[n-1].End = i
+
+			continue
+		}
+
+		groups = append(groups, SegmentGroup{Name: seg.Group, Start: i, End: i})
+	}
+
+	return groups
+}
+
+// GroupSummaries returns PB and best times for each group.
+func (a *Attempts) GroupSummaries() []GroupSummary {
+	groups := a.Groups()
+	pb := a.PersonalBestSplits()
+	best := a.BestSegments()
+	summaries := make([]GroupSummary, len(groups))
+
+	for gi, g := range groups {
+		s := GroupSummary{SegmentGroup: g}
+
+		if g.End < len(pb) {
+			s.PersonalBestMS = pb[g.End]
+			s.PersonalBestSegmentMS, _ = groupTimeMS(pb, g)
+		}
+
+		for i := g.Start; i <= g.End; i++ {
+			if best[i] == 0 {
+				s.BestSegmentMS = 0
+
+				break
+			}
+
+			s.BestSegmentMS += best[i]
+		}
+
+		summaries[gi] = s
+	}
+
+	return summaries
+}
+
+// ComputeGroupDeltas computes deltas for every group whose last segment has been split.
+func ComputeGroupDeltas(att *Attempts, currentSplitsMS []int64, comparison string) []GroupDelta {
+	compSplits := ComparisonSplits(att, comparison)
+	summaries := att.GroupSummaries()
+
+	var deltas []GroupDelta
+
+	for gi, s := range summaries {
+		if s.End >= len(currentSplitsMS) {
+			break
+		}
+
+		d := GroupDelta{GroupIndex: gi}
+
+		groupMS, ok := groupTimeMS(currentSplitsMS, s.SegmentGroup)
+		if !ok {
+			d.Skipped = true
+			deltas = append(deltas, d)
+
+			continue
+		}
+
+		d.IsBestEver = s.BestSegmentMS == 0 || groupMS < s.BestSegmentMS
+
+		if s.End < len(compSplits) && compSplits[s.End] > 0 {
+			d.DeltaMS = ComputeDelta(currentSplitsMS[s.End], compSplits[s.End])
+			d.IsAhead = d.DeltaMS < 0
+
+			if compMS, ok := groupTimeMS(compSplits, s.SegmentGroup); ok {
+				d.GainedTime = groupMS < compMS
+			}
+		}
+
+		deltas = append(deltas, d)
+	}
+
+	return deltas
+}
+
+// groupIndexOf maps each segment index to its group index, or -1 if ungrouped.
+func groupIndexOf(groups []SegmentGroup, n int) []int {
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = -1
+	}
+
+	for gi, g := range groups {
+		for i := g.Start; i <= g.End && i < n; i++ {
+			idx[i] = gi
+		}
+	}
+
+	return idx
+}
+
+// groupTimeMS returns the time spent in a group from cumulative splits: the split
+// at its last segment minus the split before its first. Returns false if either is unknown.
+func groupTimeMS(splits []int64, g SegmentGroup) (int64, bool) {
+	if g.End >= len(splits) || splits[g.End] == 0 {
+		return 0, false
+	}
+
+	var base int64
+
+	if g.Start > 0 {
+		if splits[g.Start-1] == 0 {
+			return 0, false
+		}
+
+		base = splits[g.Start-1]
+	}
+
+	if splits[g.End] <= base {
+		return 0, false
+	}
+
+	return splits[g.End] - base, true
+}
@@ -0,0 +1,115 @@
+package split
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGroups(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"1-1", "1-2", "Hub", "2-1", "2-2"})
+	att.SetSegmentGroups([]string{"World 1", "World 1", "", "World 2", "World 2"})
+	att.AddAttempt([]int64{1000, 2000, 2500, 4000, 6000}, true)
+	att.AddAttempt([]int64{800, 1900, 2600, 3600}, false)
+
+	want := []SegmentGroup{
+		{Name: "World 1", Start: 0, End: 1},
+		{Name: "World 2", Start: 3, End: 4},
+	}
+
+	if got := att.Groups(); !slices.Equal(got, want) {
+		t.Fatalf("Groups() = %v, want %v", got, want)
+	}
+
+	if att.SetSegmentGroups([]string{"World 1"}) {
+		t.Fatal("expected mismatched group count to be rejected")
+	}
+
+	att.SetSegmentGroups(nil)
+
+	if len(att.Groups()) != 0 {
+		t.Fatal("expected nil to clear groups")
+	}
+}
+
+func TestGroupSummaries(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"1-1", "1-2", "Hub", "2-1", "2-2"})
+	att.SetSegmentGroups([]string{"World 1", "World 1", "", "World 2", "World 2"})
+	att.AddAttempt([]int64{1000, 2000, 2500, 4000, 6000}, true)
+	att.AddAttempt([]int64{800, 1900, 2600, 3600}, false)
+	summaries := att.GroupSummaries()
+
+	if len(summaries) != 2 {
+		t.Fatalf("expected 2 summaries, got %d", len(summaries))
+	}
+
+	w1 := summaries[0]
+	if w1.PersonalBestMS != 2000 || w1.PersonalBestSegmentMS != 2000 {
+		t.Fatalf("unexpected World 1 PB times: %+v", w1)
+	}
+
+	// Golds: 1-1 = 800, 1-2 = 1000 (both runs).
+	if w1.BestSegmentMS != 1800 {
+		t.Fatalf("expected World 1 best 1800, got %d", w1.BestSegmentMS)
+	}
+
+	w2 := summaries[1]
+	if w2.PersonalBestMS != 6000 || w2.PersonalBestSegmentMS != 3500 {
+		t.Fatalf("unexpected World 2 PB times: %+v", w2)
+	}
+
+	// Golds: 2-1 = 1000 (3600 - 2600), 2-2 = 2000.
+	if w2.BestSegmentMS != 3000 {
+		t.Fatalf("expected World 2 best 3000, got %d", w2.BestSegmentMS)
+	}
+}
+
+func TestComputeGroupDeltas(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"1-1", "1-2", "Hub", "2-1", "2-2"})
+	att.SetSegmentGroups([]string{"World 1", "World 1", "", "World 2", "World 2"})
+	att.AddAttempt([]int64{1000, 2000, 2500, 4000, 6000}, true)
+	att.AddAttempt([]int64{800, 1900, 2600, 3600}, false)
+
+	// World 1 finished 100ms ahead of PB, World 2 not yet finished.
+	current := []int64{900, 1900, 2500, 3900}
+
+	deltas := ComputeGroupDeltas(att, current, "personal_best")
+	if len(deltas) != 1 {
+		t.Fatalf("expected 1 finished group, got %d", len(deltas))
+	}
+
+	d := deltas[0]
+	if d.DeltaMS != -100 || !d.IsAhead || !d.GainedTime {
+		t.Fatalf("unexpected World 1 delta: %+v", d)
+	}
+
+	if d.IsBestEver {
+		t.Fatal("expected 1900 not to beat World 1's sum of golds (1800)")
+	}
+
+	segDeltas := ComputeSplitDeltas(att, current, "personal_best")
+	if segDeltas[0].GroupIndex != 0 || segDeltas[2].GroupIndex != -1 || segDeltas[3].GroupIndex != 1 {
+		t.Fatalf("unexpected segment group indices: %+v", segDeltas)
+	}
+}
+
+func TestRemapSegmentsKeepsGroups(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"1-1", "1-2", "Hub", "2-1", "2-2"})
+	att.SetSegmentGroups([]string{"World 1", "World 1", "", "World 2", "World 2"})
+	att.AddAttempt([]int64{1000, 2000, 2500, 4000, 6000}, true)
+	att.AddAttempt([]int64{800, 1900, 2600, 3600}, false)
+
+	// Insert inside World 1 and right after it.
+	if !att.InsertSegment(1, "1-1b") || !att.InsertSegment(3, "Shop") {
+		t.Fatal("expected inserts to succeed")
+	}
+
+	var groups []string
+	for _, s := range att.Segments {
+		groups = append(groups, s.Group)
+	}
+
+	want := []string{"World 1", "World 1", "World 1", "", "", "World 2", "World 2"}
+	if !slices.Equal(groups, want) {
+		t.Fatalf("groups after insert = %v, want %v", groups, want)
+	}
+}
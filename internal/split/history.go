@@ -0,0 +1,53 @@
+package split
+
+import (
+	"slices"
+	"time"
+)
+
+// HistoryFilter selects attempts from History. Zero-valued fields match every attempt.
+type HistoryFilter struct {
+	Reasons       []EndReason `json:"reasons"`       // Match any of these end reasons.
+	EndedSegments []int       `json:"endedSegments"` // Match runs that ended on any of these segments.
+	Tag           string      `json:"tag"`
+	StartedAfter  time.Time   `json:"startedAfter"`
+	StartedBefore time.Time   `json:"startedBefore"`
+}
+
+// Matches reports whether the attempt passes every set field of the filter.
+func (f HistoryFilter) Matches(at Attempt) bool {
+	if len(f.Reasons) > 0 && !slices.Contains(f.Reasons, at.Reason()) {
+		return false
+	}
+
+	if len(f.EndedSegments) > 0 && !slices.Contains(f.EndedSegments, at.EndedSegment) {
+		return false
+	}
+
+	if f.Tag != "" && !at.HasTag(f.Tag) {
+		return false
+	}
+
+	if !f.StartedAfter.IsZero() && at.StartedAt.Before(f.StartedAfter) {
+		return false
+	}
+
+	if !f.StartedBefore.IsZero() && !at.StartedAt.Before(f.StartedBefore) {
+		return false
+	}
+
+	return true
+}
+
+// FilterHistory returns the attempts matching the filter, oldest first.
+func (a *Attempts) FilterHistory(f HistoryFilter) []Attempt {
+	var result []Attempt
+
+	for _, att := range a.History {
+		if f.Matches(att) {
+			result = append(result, att)
+		}
+	}
+
+	return result
+}
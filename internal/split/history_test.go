@@ -0,0 +1,40 @@
+package split
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilterHistory(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	day := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	att.RecordAttempt([]int64{1000}, false, AttemptEnd{Reason: EndReset, Segment: 1, StartedAt: day})
+	att.RecordAttempt([]int64{1000, 2000}, false, AttemptEnd{Reason: EndCrashRecovered, Segment: 2, StartedAt: day.Add(time.Hour)})
+	att.RecordAttempt([]int64{1000, 2000, 3000}, true, AttemptEnd{Reason: EndFinished, Segment: 2, StartedAt: day.Add(48 * time.Hour)})
+	att.EditAttemptMetadata(3, AttemptMetadata{Tags: []string{"race"}})
+
+	if got := att.FilterHistory(HistoryFilter{}); len(got) != 3 {
+		t.Fatalf("expected empty filter to match all 3 attempts, got %d", len(got))
+	}
+
+	got := att.FilterHistory(HistoryFilter{Reasons: []EndReason{EndReset, EndCrashRecovered}})
+	if len(got) != 2 || got[0].ID != 1 || got[1].ID != 2 {
+		t.Fatalf("expected attempts 1 and 2 by reason, got %v", got)
+	}
+
+	got = att.FilterHistory(HistoryFilter{EndedSegments: []int{2}})
+	if len(got) != 2 || got[0].ID != 2 || got[1].ID != 3 {
+		t.Fatalf("expected attempts 2 and 3 by ended segment, got %v", got)
+	}
+
+	got = att.FilterHistory(HistoryFilter{StartedAfter: day.Add(time.Minute), StartedBefore: day.Add(24 * time.Hour)})
+	if len(got) != 1 || got[0].ID != 2 {
+		t.Fatalf("expected attempt 2 by start window, got %v", got)
+	}
+
+	got = att.FilterHistory(HistoryFilter{Tag: "race", Reasons: []EndReason{EndFinished}})
+	if len(got) != 1 || got[0].ID != 3 {
+		t.Fatalf("expected attempt 3 by tag and reason, got %v", got)
+	}
+}
@@ -0,0 +1,164 @@
+package split
+
+import (
+	"slices"
+	"time"
+)
+
+// HitDelta is the hit-counting counterpart of Delta for no-hit categories.
+type HitDelta struct {
+	SegmentIndex int  `json:"segmentIndex"`
+	GroupIndex   int  `json:"groupIndex"` // Index into Attempts.Groups, or -1 if the segment is ungrouped.
+	Hits         int  `json:"hits"`       // Hits taken in the segment.
+	DeltaHits    int  `json:"deltaHits"`  // Cumulative delta. Positive = behind, negative = ahead.
+	IsBestEver   bool `json:"isBestEver"` // True if no earlier run took fewer hits in the segment.
+	IsAhead      bool `json:"isAhead"`    // True if fewer hits than the comparison so far.
+	GainedHits   bool `json:"gainedHits"` // True if the segment took fewer hits than the comparison's.
+}
+
+// SetHitCounting switches the category between timed runs and no-hit runs,
+// where PB, golds and deltas count hits instead of time.
+func (a *Attempts) SetHitCounting(enabled bool) {
+	a.HitCounting = enabled
+	a.UpdatedAt = time.Now()
+}
+
+// TotalHits returns the sum of per-segment hit counts.
+func TotalHits(hits []int) int {
+	var total int
+	for _, h := range hits {
+		total += h
+	}
+
+	return total
+}
+
+// PersonalBestHits returns the per-segment hits of the completed attempt with
+// the fewest total hits, or nil if none was hit-counted. Ties go to the faster
+// run. Runs that skipped a segment never count: its hits were not played out,
+// so their total is not comparable.
+func (a *Attempts) PersonalBestHits() []int {
+	var pb *Attempt
+
+	for i, att := range a.History {
+		if !att.Completed || att.IsPartialStart() || att.Hits == nil || slices.Contains(att.SplitTimesMS, 0) {
+			continue
+		}
+
+		if pb == nil || fewerHits(att, *pb) {
+			pb = &a.History[i]
+		}
+	}
+
+	if pb == nil {
+		return nil
+	}
+
+	hits := make([]int, len(a.Segments))
+	copy(hits, pb.Hits)
+
+	return hits
+}
+
+func fewerHits(at, than Attempt) bool {
+	if a, b := TotalHits(at.Hits), TotalHits(than.Hits); a != b {
+		return a < b
+	}
+
+	return finalSplit(at) < finalSplit(than)
+}
+
+func finalSplit(at Attempt) int64 {
+	if len(at.SplitTimesMS) == 0 || at.SplitTimesMS[len(at.SplitTimesMS)-1] == 0 {
+		return 1<<63 - 1
+	}
+
+	return at.SplitTimesMS[len(at.SplitTimesMS)-1]
+}
+
+// BestSegmentHits returns the fewest hits any hit-counted attempt took in each
+// segment it split, or BaselineHits if fewer. Skipped segments are ignored.
+// -1 means no data, since 0 hits is a valid gold.
+func (a *Attempts) BestSegmentHits() []int {
+	best := make([]int, len(a.Segments))
+	for i := range best {
+		best[i] = -1
+		if i < len(a.BaselineHits) {
+			best[i] = a.BaselineHits[i]
+		}
+	}
+
+	for _, att := range a.History {
+		if att.Hits == nil || att.ExcludeGolds {
+			continue
+		}
+
+		for i := att.StartSegment; i < len(att.SplitTimesMS) && i < len(att.Hits) && i < len(best); i++ {
+			if att.SplitTimesMS[i] == 0 {
+				continue
+			}
+
+			if best[i] < 0 || att.Hits[i] < best[i] {
+				best[i] = att.Hits[i]
+			}
+		}
+	}
+
+	return best
+}
+
+// ComparisonHits returns the reference hits per segment: the golds for
+// "best_segments" and the PB for everything else. Nil if there is no reference.
+func ComparisonHits(att *Attempts, comparison string) []int {
+	if comparison != "best_segments" {
+		return att.PersonalBestHits()
+	}
+
+	best := att.BestSegmentHits()
+	if !slices.ContainsFunc(best, func(h int) bool { return h >= 0 }) {
+		return nil
+	}
+
+	return best
+}
+
+// ComputeHitDeltas computes hit deltas for the first passed segments of a run,
+// given the hits taken in each segment so far.
+func ComputeHitDeltas(att *Attempts, hits []int, passed int, comparison string) []HitDelta {
+	comp := ComparisonHits(att, comparison)
+	best := att.BestSegmentHits()
+	groupOf := groupIndexOf(att.Groups(), passed)
+	deltas := make([]HitDelta, passed)
+
+	var total, compTotal int
+
+	known := comp != nil
+
+	for i := range passed {
+		d := HitDelta{SegmentIndex: i, GroupIndex: groupOf[i]}
+		if i < len(hits) {
+			d.Hits = hits[i]
+		}
+
+		total += d.Hits
+
+		if i < len(best) {
+			d.IsBestEver = best[i] < 0 || d.Hits <= best[i]
+		}
+
+		if known && (i >= len(comp) || comp[i] < 0) {
+			known = false
+		}
+
+		if known {
+			compTotal += comp[i]
+			d.DeltaHits = total - compTotal
+			d.IsAhead = d.DeltaHits < 0
+			d.GainedHits = d.Hits < comp[i]
+		}
+
+		deltas[i] = d
+	}
+
+	return deltas
+}
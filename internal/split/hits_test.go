@@ -0,0 +1,107 @@
+package split
+
+import "testing"
+
+func TestPersonalBestHits(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "No-hit", []string{"A", "B", "C"})
+	att.SetHitCounting(true)
+	att.RecordAttempt([]int64{1000, 2000, 3000}, true, AttemptEnd{Reason: EndFinished, Segment: 2, Hits: []int{1, 2, 1}})
+	att.RecordAttempt([]int64{900, 1900, 2500}, true, AttemptEnd{Reason: EndFinished, Segment: 2, Hits: []int{2, 0, 3}})
+	att.RecordAttempt([]int64{1100}, false, AttemptEnd{Reason: EndReset, Segment: 1, Hits: []int{0, 4, 0}})
+
+	pb := att.PersonalBestHits()
+
+	// 4 total hits beats 5, even though the 5-hit run was faster.
+	if len(pb) != 3 || pb[0] != 1 || pb[1] != 2 || pb[2] != 1 {
+		t.Fatalf("unexpected PB hits: %v", pb)
+	}
+
+	timed := NewAttempts("a-2", "t-1", "", "Any%", []string{"A"})
+	timed.AddAttempt([]int64{1000}, true)
+
+	if timed.PersonalBestHits() != nil {
+		t.Fatal("expected no PB hits without hit-counted attempts")
+	}
+}
+
+func TestBestSegmentHits(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "No-hit", []string{"A", "B", "C"})
+	att.SetHitCounting(true)
+	att.RecordAttempt([]int64{1000, 2000, 3000}, true, AttemptEnd{Reason: EndFinished, Segment: 2, Hits: []int{1, 2, 1}})
+	att.RecordAttempt([]int64{900, 1900, 2500}, true, AttemptEnd{Reason: EndFinished, Segment: 2, Hits: []int{2, 0, 3}})
+	att.RecordAttempt([]int64{1100}, false, AttemptEnd{Reason: EndReset, Segment: 1, Hits: []int{0, 4, 0}})
+
+	best := att.BestSegmentHits()
+
+	// The reset run got past A hitless but never finished B.
+	if best[0] != 0 || best[1] != 0 || best[2] != 1 {
+		t.Fatalf("unexpected golds: %v", best)
+	}
+
+	if b := NewAttempts("a-2", "t-1", "", "No-hit", []string{"A"}).BestSegmentHits(); b[0] != -1 {
+		t.Fatalf("expected -1 without data, got %v", b)
+	}
+}
+
+func TestBestSegmentHitsIgnoresSkips(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "No-hit", []string{"A", "B", "C"})
+	att.SetHitCounting(true)
+	att.RecordAttempt([]int64{1000, 2000, 3000}, true, AttemptEnd{Reason: EndFinished, Segment: 2, Hits: []int{1, 2, 1}})
+	att.RecordAttempt([]int64{900, 0, 2500}, true, AttemptEnd{Reason: EndFinished, Segment: 2, Hits: []int{1, 0, 1}})
+
+	if best := att.BestSegmentHits(); best[1] != 2 {
+		t.Fatalf("expected a skipped segment not to set a gold, got %v", best)
+	}
+}
+
+func TestPersonalBestHitsIgnoresSkips(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "No-hit", []string{"A", "B", "C"})
+	att.SetHitCounting(true)
+	att.RecordAttempt([]int64{1000, 2000, 3000}, true, AttemptEnd{Reason: EndFinished, Segment: 2, Hits: []int{1, 2, 1}})
+	att.RecordAttempt([]int64{900, 0, 2500}, true, AttemptEnd{Reason: EndFinished, Segment: 2, Hits: []int{0, 0, 0}})
+
+	if pb := att.PersonalBestHits(); len(pb) != 3 || pb[1] != 2 {
+		t.Fatalf("expected the run without skips to be the PB, got %v", pb)
+	}
+}
+
+func TestComputeHitDeltas(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "No-hit", []string{"A", "B", "C"})
+	att.SetHitCounting(true)
+	att.RecordAttempt([]int64{1000, 2000, 3000}, true, AttemptEnd{Reason: EndFinished, Segment: 2, Hits: []int{1, 2, 1}})
+	att.RecordAttempt([]int64{900, 1900, 2500}, true, AttemptEnd{Reason: EndFinished, Segment: 2, Hits: []int{2, 0, 3}})
+	att.RecordAttempt([]int64{1100}, false, AttemptEnd{Reason: EndReset, Segment: 1, Hits: []int{0, 4, 0}})
+
+	d := ComputeHitDeltas(att, []int{0, 3, 0}, 2, "personal_best")
+
+	if len(d) != 2 {
+		t.Fatalf("expected deltas for passed segments only, got %d", len(d))
+	}
+
+	if d[0].DeltaHits != -1 || !d[0].IsAhead || !d[0].GainedHits || !d[0].IsBestEver {
+		t.Fatalf("unexpected first delta: %+v", d[0])
+	}
+
+	if d[1].DeltaHits != 0 || d[1].IsAhead || d[1].GainedHits || d[1].IsBestEver {
+		t.Fatalf("unexpected second delta: %+v", d[1])
+	}
+
+	d = ComputeHitDeltas(att, []int{1, 0}, 2, "best_segments")
+	if d[0].DeltaHits != 1 || d[1].DeltaHits != 1 || !d[1].IsBestEver {
+		t.Fatalf("unexpected deltas against golds: %+v", d)
+	}
+}
+
+func TestRemapHits(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "No-hit", []string{"A", "B", "C"})
+	att.SetHitCounting(true)
+	att.RecordAttempt([]int64{1000, 2000, 3000}, true, AttemptEnd{Reason: EndFinished, Segment: 2, Hits: []int{1, 2, 1}})
+	att.RecordAttempt([]int64{900, 1900, 2500}, true, AttemptEnd{Reason: EndFinished, Segment: 2, Hits: []int{2, 0, 3}})
+	att.RecordAttempt([]int64{1100}, false, AttemptEnd{Reason: EndReset, Segment: 1, Hits: []int{0, 4, 0}})
+
+	att.RemapSegments([]string{"AB", "C"}, [][]int{{0, 1}, {2}})
+
+	if h := att.History[0].Hits; len(h) != 2 || h[0] != 3 || h[1] != 1 {
+		t.Fatalf("expected merged hits to add up, got %v", h)
+	}
+}
@@ -0,0 +1,64 @@
+package split
+
+import (
+	"slices"
+	"time"
+)
+
+// ILResult describes a just-recorded IL run against the segment's earlier IL runs.
+type ILResult struct {
+	SegmentRun
+	DeltaMS int64 `json:"deltaMs"` // Against the previous IL best; 0 if there was none.
+	IsBest  bool  `json:"isBest"`
+	Rank    int   `json:"rank"` // 1-based position among the segment's IL runs.
+}
+
+// ILStanding is one leaderboard row: a segment's best IL times.
+type ILStanding struct {
+	SegmentIndex int          `json:"segmentIndex"`
+	Segment      string       `json:"segment"`
+	Top          []SegmentRun `json:"top"`  // Fastest first.
+	Runs         int          `json:"runs"` // Total IL runs of the segment.
+}
+
+// RecordIL records an individual level run of the segment with the given ID
+// and compares it with the earlier runs.
+func (l *SegmentLog) RecordIL(segmentID int, timeMS int64, at time.Time) ILResult {
+	run := SegmentRun{SegmentID: segmentID, TimeMS: timeMS, RecordedAt: at}
+	res := ILResult{SegmentRun: run, IsBest: true, Rank: 1}
+
+	if best, ok := l.Best(segmentID); ok {
+		res.DeltaMS = timeMS - best.TimeMS
+		res.IsBest = timeMS < best.TimeMS
+	}
+
+	for _, prev := range l.runsOf(segmentID) {
+		if prev.TimeMS <= timeMS {
+			res.Rank++
+		}
+	}
+
+	l.Runs = append(l.Runs, run)
+
+	return res
+}
+
+// Leaderboard returns the top IL times of each segment, in segment order.
+// top <= 0 returns every run.
+func (l *SegmentLog) Leaderboard(segments []Segment, top int) []ILStanding {
+	standings := make([]ILStanding, len(segments))
+
+	for i, seg := range segments {
+		runs := l.runsOf(seg.ID)
+		count := len(runs)
+		slices.SortStableFunc(runs, compareSegmentRuns)
+
+		if top > 0 && count > top {
+			runs = runs[:top]
+		}
+
+		standings[i] = ILStanding{SegmentIndex: i, Segment: seg.Name, Top: runs, Runs: count}
+	}
+
+	return standings
+}
@@ -0,0 +1,84 @@
+package split
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordIL(t *testing.T) {
+	r := NewSegmentLog("a-1")
+	now := time.Now()
+
+	first := r.RecordIL(1, 5000, now)
+	if !first.IsBest || first.DeltaMS != 0 || first.Rank != 1 {
+		t.Fatalf("unexpected first result: %+v", first)
+	}
+
+	slower := r.RecordIL(1, 5400, now)
+	if slower.IsBest || slower.DeltaMS != 400 || slower.Rank != 2 {
+		t.Fatalf("unexpected slower result: %+v", slower)
+	}
+
+	faster := r.RecordIL(1, 4800, now)
+	if !faster.IsBest || faster.DeltaMS != -200 || faster.Rank != 1 {
+		t.Fatalf("unexpected faster result: %+v", faster)
+	}
+
+	// Other segments are compared separately.
+	if other := r.RecordIL(2, 9000, now); !other.IsBest || other.Rank != 1 {
+		t.Fatalf("unexpected result for another segment: %+v", other)
+	}
+
+	if best, ok := r.Best(1); !ok || best.TimeMS != 4800 {
+		t.Fatalf("unexpected best: %+v", best)
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	r := NewSegmentLog("a-1")
+	now := time.Now()
+
+	for _, ms := range []int64{5000, 4800, 5200} {
+		r.RecordIL(1, ms, now)
+	}
+
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"1-1", "1-2"})
+
+	board := r.Leaderboard(att.Segments, 2)
+	if len(board) != 2 {
+		t.Fatalf("expected 2 standings, got %d", len(board))
+	}
+
+	if board[0].Runs != 3 || len(board[0].Top) != 2 || board[0].Top[0].TimeMS != 4800 || board[0].Top[1].TimeMS != 5000 {
+		t.Fatalf("unexpected standing: %+v", board[0])
+	}
+
+	if board[1].Runs != 0 || len(board[1].Top) != 0 {
+		t.Fatalf("expected no runs for 1-2, got %+v", board[1])
+	}
+}
+
+func TestSegmentLogFollowsSegmentEdits(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"Castle", "Castle", "Boss"})
+	r := NewSegmentLog(att.ID)
+
+	r.RecordIL(att.Segments[1].ID, 7000, time.Now())
+
+	// The second "Castle" is renamed and moved to the front.
+	if !att.RemapSegments([]string{"Castle 2", "Castle", "Boss"}, [][]int{{1}, {0}, {2}}) {
+		t.Fatal("remap failed")
+	}
+
+	board := r.Leaderboard(att.Segments, 0)
+	if board[0].Segment != "Castle 2" || board[0].Runs != 1 || board[1].Runs != 0 {
+		t.Fatalf("expected the run to follow its segment, got %+v", board)
+	}
+
+	// A segment inserted after a removal never takes over the removed segment's runs.
+	att.RemoveSegment(0)
+	att.InsertSegment(0, "Castle 2")
+
+	if board := r.Leaderboard(att.Segments, 0); board[0].Runs != 0 {
+		t.Fatalf("expected the inserted segment to have no runs, got %+v", board[0])
+	}
+}
@@ -0,0 +1,131 @@
+package split
+
+import "time"
+
+// MarathonLeg is one game of a marathon template, backed by that game's attempts entry.
+type MarathonLeg struct {
+	AttemptsID   string `json:"attemptsId"`
+	SegmentCount int    `json:"segmentCount"` // Segments the leg spans in the marathon.
+}
+
+// LegRun is the part of a marathon attempt that belongs to one leg, with
+// splits relative to the leg's start.
+type LegRun struct {
+	Leg          int     `json:"leg"`
+	SplitTimesMS []int64 `json:"splitTimesMs"`
+	Completed    bool    `json:"completed"`
+	EndedSegment int     `json:"endedSegment"` // Relative to the leg.
+	StartSegment int     `json:"startSegment"` // Relative to the leg; non-zero if the marathon started inside it.
+	OffsetMS     int64   `json:"offsetMs"`     // Marathon time when the leg started.
+	Hits         []int   `json:"hits,omitempty"`
+}
+
+// NewMarathonTemplate chains attempts entries into one template. Segments are
+// concatenated in order and grouped by legNames[i], typically the game name.
+func NewMarathonTemplate(id, name string, legs []*Attempts, legNames []string) *Template {
+	var names, groups []string
+
+	t := NewTemplate(id, name, nil)
+
+	for i, att := range legs {
+		for _, seg := range att.Segments {
+			names = append(names, seg.Name)
+			groups = append(groups, legNames[i])
+		}
+
+		t.Legs = append(t.Legs, MarathonLeg{AttemptsID: att.ID, SegmentCount: len(att.Segments)})
+	}
+
+	t.SegmentNames = names
+	t.SegmentGroups = groups
+
+	return t
+}
+
+// IsMarathon reports whether the template chains other categories.
+func (t *Template) IsMarathon() bool {
+	return len(t.Legs) > 0
+}
+
+// MarathonLegRuns splits a marathon attempt into the runs of the legs it reached,
+// using the segments end records. Legs skipped over by a partial start, and legs
+// whose start time is unknown because the split before them was skipped, are left out.
+func MarathonLegRuns(legs []MarathonLeg, splits []int64, completed bool, end AttemptEnd) []LegRun {
+	endedSegment := end.Segment
+
+	var runs []LegRun
+
+	offset := 0
+
+	for li, leg := range legs {
+		start := offset
+		offset += leg.SegmentCount
+
+		if !completed && endedSegment < start {
+			break
+		}
+
+		if offset <= end.StartSegment {
+			continue
+		}
+
+		var base int64
+		if start > 0 {
+			if start > len(splits) || splits[start-1] == 0 {
+				continue
+			}
+
+			base = splits[start-1]
+		}
+
+		stop := min(offset, len(splits))
+		run := LegRun{Leg: li, OffsetMS: base, SplitTimesMS: make([]int64, 0, stop-start)}
+
+		for _, ms := range splits[start:stop] {
+			if ms != 0 {
+				ms -= base
+			}
+
+			run.SplitTimesMS = append(run.SplitTimesMS, ms)
+		}
+
+		run.Completed = len(splits) >= offset
+		run.EndedSegment = min(endedSegment, offset-1) - start
+		run.StartSegment = max(end.StartSegment-start, 0)
+
+		if end.Hits != nil {
+			run.Hits = make([]int, leg.SegmentCount)
+			copy(run.Hits, end.Hits[min(start, len(end.Hits)):min(offset, len(end.Hits))])
+		}
+
+		runs = append(runs, run)
+	}
+
+	return runs
+}
+
+// LegEnd derives a leg's AttemptEnd from the marathon attempt's end.
+func (r LegRun) LegEnd(end AttemptEnd) AttemptEnd {
+	leg := AttemptEnd{
+		Reason:    end.Reason,
+		Segment:   r.EndedSegment,
+		StartedAt: end.StartedAt.Add(time.Duration(r.OffsetMS) * time.Millisecond),
+		EndedAt:   end.EndedAt,
+		ElapsedMS: max(end.ElapsedMS-r.OffsetMS, 0),
+
+		StartSegment: r.StartSegment,
+		ExcludeGolds: end.ExcludeGolds,
+		Hits:         r.Hits,
+	}
+
+	if r.Completed {
+		leg.Reason = EndFinished
+
+		if n := len(r.SplitTimesMS); n > 0 && r.SplitTimesMS[n-1] != 0 {
+			leg.ElapsedMS = r.SplitTimesMS[n-1]
+			leg.EndedAt = leg.StartedAt.Add(time.Duration(leg.ElapsedMS) * time.Millisecond)
+		}
+	}
+
+	return leg
+}
@@ -0,0 +1,97 @@
+package split
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func marathonLegs() []MarathonLeg {
+	return []MarathonLeg{{AttemptsID: "a-1", SegmentCount: 2}, {AttemptsID: "a-2", SegmentCount: 2}}
+}
+
+func TestNewMarathonTemplate(t *testing.T) {
+	g1 := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+	g2 := NewAttempts("a-2", "t-2", "", "Any%", []string{"C"})
+
+	tmpl := NewMarathonTemplate("m-1", "Marathon", []*Attempts{g1, g2}, []string{"Game 1", "Game 2"})
+
+	if !slices.Equal(tmpl.SegmentNames, []string{"A", "B", "C"}) {
+		t.Fatalf("unexpected segments: %v", tmpl.SegmentNames)
+	}
+
+	if !slices.Equal(tmpl.SegmentGroups, []string{"Game 1", "Game 1", "Game 2"}) {
+		t.Fatalf("unexpected groups: %v", tmpl.SegmentGroups)
+	}
+
+	if !tmpl.IsMarathon() || tmpl.Legs[1].SegmentCount != 1 {
+		t.Fatalf("unexpected legs: %+v", tmpl.Legs)
+	}
+}
+
+func TestMarathonLegRunsCompleted(t *testing.T) {
+	runs := MarathonLegRuns(marathonLegs(), []int64{1000, 3000, 4000, 7000}, true, AttemptEnd{Segment: 3})
+
+	if len(runs) != 2 {
+		t.Fatalf("expected 2 leg runs, got %d", len(runs))
+	}
+
+	if !slices.Equal(runs[1].SplitTimesMS, []int64{1000, 4000}) || runs[1].OffsetMS != 3000 || !runs[1].Completed {
+		t.Fatalf("unexpected second leg: %+v", runs[1])
+	}
+}
+
+func TestMarathonLegRunsReset(t *testing.T) {
+	// Reset on the second leg's second segment.
+	runs := MarathonLegRuns(marathonLegs(), []int64{1000, 3000, 4000}, false, AttemptEnd{Segment: 3})
+
+	if len(runs) != 2 || !runs[0].Completed {
+		t.Fatalf("expected a finished first leg, got %+v", runs)
+	}
+
+	if runs[1].Completed || runs[1].EndedSegment != 1 || !slices.Equal(runs[1].SplitTimesMS, []int64{1000}) {
+		t.Fatalf("unexpected reset leg: %+v", runs[1])
+	}
+
+	// Reset before reaching the second leg.
+	if runs := MarathonLegRuns(marathonLegs(), []int64{1000}, false, AttemptEnd{Segment: 1}); len(runs) != 1 {
+		t.Fatalf("expected only the first leg, got %+v", runs)
+	}
+}
+
+func TestMarathonLegRunsSkippedBoundary(t *testing.T) {
+	// The first leg's last split was skipped, so the second leg's start is unknown.
+	runs := MarathonLegRuns(marathonLegs(), []int64{1000, 0, 4000, 7000}, true, AttemptEnd{Segment: 3})
+
+	if len(runs) != 1 || runs[0].Leg != 0 {
+		t.Fatalf("expected only the first leg, got %+v", runs)
+	}
+}
+
+func TestLegRunLegEnd(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	run := LegRun{Leg: 1, SplitTimesMS: []int64{1000, 4000}, Completed: true, EndedSegment: 1, OffsetMS: 3000}
+
+	end := run.LegEnd(AttemptEnd{Reason: EndReset, StartedAt: start, EndedAt: start.Add(time.Minute), ElapsedMS: 60000})
+
+	if end.Reason != EndFinished || end.ElapsedMS != 4000 {
+		t.Fatalf("unexpected leg end: %+v", end)
+	}
+
+	if !end.StartedAt.Equal(start.Add(3*time.Second)) || !end.EndedAt.Equal(start.Add(7*time.Second)) {
+		t.Fatalf("unexpected leg times: %v - %v", end.StartedAt, end.EndedAt)
+	}
+}
+
+func TestMarathonLegRunsPartialStart(t *testing.T) {
+	// Started on the second leg's second segment with the first three splits preloaded.
+	runs := MarathonLegRuns(marathonLegs(), []int64{1000, 3000, 4000, 7000}, true, AttemptEnd{Segment: 3, StartSegment: 3})
+
+	if len(runs) != 1 || runs[0].Leg != 1 || runs[0].StartSegment != 1 {
+		t.Fatalf("expected only a partial second leg, got %+v", runs)
+	}
+
+	if end := runs[0].LegEnd(AttemptEnd{StartSegment: 3}); end.StartSegment != 1 {
+		t.Fatalf("expected the leg attempt to be a partial start, got %+v", end)
+	}
+}
@@ -0,0 +1,61 @@
+package split
+
+// PlayTime totals the time spent on a set of attempts.
+type PlayTime struct {
+	Attempts    int   `json:"attempts"`
+	TotalMS     int64 `json:"totalMs"`
+	CompletedMS int64 `json:"completedMs"` // Time spent in runs that finished.
+	ResetMS     int64 `json:"resetMs"`     // Time spent in runs that did not finish.
+	PausedMS    int64 `json:"pausedMs"`    // Pause time, counted in the totals only when requested.
+}
+
+// Add accumulates other into p.
+func (p *PlayTime) Add(other PlayTime) {
+	p.Attempts += other.Attempts
+	p.TotalMS += other.TotalMS
+	p.CompletedMS += other.CompletedMS
+	p.ResetMS += other.ResetMS
+	p.PausedMS += other.PausedMS
+}
+
+// PlayTimeMS returns how long the attempt was played: the timer value when it
+// ended, so a reset counts the segment in progress. Attempts recorded before the
+// end value was kept fall back to their last split. A partial start's timer
+// began at its preloaded split, which was never played and is left out.
+func (at Attempt) PlayTimeMS(includePause bool) int64 {
+	var ms int64
+
+	if at.ElapsedMS <= 0 {
+		ms, _ = lastNonZeroBefore(at.SplitTimesMS, len(at.SplitTimesMS))
+	} else {
+		ms = at.ElapsedMS
+		if includePause {
+			ms += at.PausedMS
+		}
+	}
+
+	preloaded, _ := lastNonZeroBefore(at.SplitTimesMS, min(at.StartSegment, len(at.SplitTimesMS)))
+
+	return max(ms-preloaded, 0)
+}
+
+// PlayTime sums the play time of every attempt in History, including resets.
+func (a *Attempts) PlayTime(includePause bool) PlayTime {
+	var p PlayTime
+
+	for _, att := range a.History {
+		ms := att.PlayTimeMS(includePause)
+
+		p.Attempts++
+		p.TotalMS += ms
+		p.PausedMS += att.PausedMS
+
+		if att.Completed {
+			p.CompletedMS += ms
+		} else {
+			p.ResetMS += ms
+		}
+	}
+
+	return p
+}
@@ -0,0 +1,42 @@
+package split
+
+import "testing"
+
+func TestAttemptsPlayTime(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+	att.RecordAttempt([]int64{1000, 2000}, true, AttemptEnd{Reason: EndFinished, ElapsedMS: 2000, PausedMS: 300})
+
+	// Reset partway through B: the unsplit part of B still counts.
+	att.RecordAttempt([]int64{1000}, false, AttemptEnd{Reason: EndReset, ElapsedMS: 1800})
+
+	// Legacy attempt without an elapsed value.
+	att.AddAttempt([]int64{900, 0}, false)
+
+	pt := att.PlayTime(false)
+	if pt.Attempts != 3 || pt.TotalMS != 2000+1800+900 {
+		t.Fatalf("unexpected play time: %+v", pt)
+	}
+
+	if pt.CompletedMS != 2000 || pt.ResetMS != 2700 {
+		t.Fatalf("unexpected completed/reset split: %+v", pt)
+	}
+
+	if got := att.PlayTime(true).TotalMS; got != pt.TotalMS+300 {
+		t.Fatalf("expected pause to be included, got %d", got)
+	}
+}
+
+func TestPartialStartPlayTime(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+
+	// Started at C with A and B preloaded; the timer began at 2000.
+	att.RecordAttempt([]int64{1000, 2000, 2600}, true, AttemptEnd{Reason: EndFinished, ElapsedMS: 2600, PausedMS: 100, StartSegment: 2})
+
+	if got := att.PlayTime(false).TotalMS; got != 600 {
+		t.Fatalf("expected only C to count, got %d", got)
+	}
+
+	if got := att.PlayTime(true).TotalMS; got != 700 {
+		t.Fatalf("expected C plus pause, got %d", got)
+	}
+}
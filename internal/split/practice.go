@@ -0,0 +1,79 @@
+package split
+
+import (
+	"slices"
+	"time"
+)
+
+// PracticeResult compares a just-recorded practice run with the segment's gold
+// and the median of its earlier practice runs.
+type PracticeResult struct {
+	SegmentRun
+	GoldMS        int64 `json:"goldMs"`        // Gold from full runs, 0 if none.
+	DeltaGoldMS   int64 `json:"deltaGoldMs"`   // 0 if there is no gold.
+	MedianMS      int64 `json:"medianMs"`      // Median of earlier practice runs, 0 if none.
+	DeltaMedianMS int64 `json:"deltaMedianMs"` // 0 if there were no earlier runs.
+}
+
+// PracticeSummary sums up the practice runs of one segment.
+type PracticeSummary struct {
+	SegmentIndex int    `json:"segmentIndex"`
+	Segment      string `json:"segment"`
+	Runs         int    `json:"runs"`
+	BestMS       int64  `json:"bestMs"`
+	MedianMS     int64  `json:"medianMs"`
+}
+
+// RecordPractice records a practice run of the segment with the given ID.
+// goldMS is the segment's gold from full runs.
+func (l *SegmentLog) RecordPractice(segmentID int, timeMS, goldMS int64, at time.Time) PracticeResult {
+	res := PracticeResult{
+		SegmentRun: SegmentRun{SegmentID: segmentID, TimeMS: timeMS, RecordedAt: at},
+		GoldMS:     goldMS,
+		MedianMS:   medianMS(l.timesOf(segmentID)),
+	}
+
+	if goldMS > 0 {
+		res.DeltaGoldMS = timeMS - goldMS
+	}
+
+	if res.MedianMS > 0 {
+		res.DeltaMedianMS = timeMS - res.MedianMS
+	}
+
+	l.Runs = append(l.Runs, res.SegmentRun)
+
+	return res
+}
+
+// Summaries returns practice totals for each segment, in segment order.
+func (l *SegmentLog) Summaries(segments []Segment) []PracticeSummary {
+	summaries := make([]PracticeSummary, len(segments))
+
+	for i, seg := range segments {
+		times := l.timesOf(seg.ID)
+		summaries[i] = PracticeSummary{SegmentIndex: i, Segment: seg.Name, Runs: len(times), MedianMS: medianMS(times)}
+
+		if len(times) > 0 {
+			summaries[i].BestMS = slices.Min(times)
+		}
+	}
+
+	return summaries
+}
+
+// medianMS returns the median of times, or 0 if empty. times is sorted in place.
+func medianMS(times []int64) int64 {
+	if len(times) == 0 {
+		return 0
+	}
+
+	slices.Sort(times)
+
+	mid := len(times) / 2
+	if len(times)%2 == 1 {
+		return times[mid]
+	}
+
+	return (times[mid-1] + times[mid]) / 2
+}
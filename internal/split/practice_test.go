@@ -0,0 +1,53 @@
+package split
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecordPractice(t *testing.T) {
+	l := NewSegmentLog("a-1")
+	now := time.Now()
+
+	first := l.RecordPractice(2, 10000, 9000, now)
+	if first.DeltaGoldMS != 1000 || first.MedianMS != 0 || first.DeltaMedianMS != 0 {
+		t.Fatalf("unexpected first result: %+v", first)
+	}
+
+	l.RecordPractice(2, 12000, 9000, now)
+
+	// Median of the earlier runs (10000, 12000) is 11000.
+	third := l.RecordPractice(2, 10500, 9000, now)
+	if third.MedianMS != 11000 || third.DeltaMedianMS != -500 {
+		t.Fatalf("unexpected third result: %+v", third)
+	}
+
+	if noGold := l.RecordPractice(1, 3000, 0, now); noGold.DeltaGoldMS != 0 {
+		t.Fatalf("expected no gold delta without a gold, got %+v", noGold)
+	}
+}
+
+func TestPracticeSummaries(t *testing.T) {
+	l := NewSegmentLog("a-1")
+	now := time.Now()
+
+	for _, ms := range []int64{12000, 10000, 11000} {
+		l.RecordPractice(2, ms, 0, now)
+	}
+
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"Intro", "Boss"})
+
+	sums := l.Summaries(att.Segments)
+	if sums[0].Runs != 0 || sums[0].BestMS != 0 {
+		t.Fatalf("unexpected Intro summary: %+v", sums[0])
+	}
+
+	if sums[1].Runs != 3 || sums[1].BestMS != 10000 || sums[1].MedianMS != 11000 {
+		t.Fatalf("unexpected Boss summary: %+v", sums[1])
+	}
+
+	// Summaries must not reorder the log.
+	if l.Runs[0].TimeMS != 12000 {
+		t.Fatalf("expected the log to keep recording order, got %+v", l.Runs)
+	}
+}
@@ -0,0 +1,392 @@
+package split
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// RemapSegments replaces the segment list and rewrites every attempt's splits so
+// that PB and golds carry over to the new layout.
+//
+// sources[j] lists the current segment indices that make up new segment j. One
+// index keeps (or renames) a segment, several adjacent indices merge them, and an
+// empty list inserts a segment whose times are unknown. Current segments that are
+// not listed are deleted; their time folds into the next kept segment.
+//
+// Segments keep the ID of their first source; inserted segments get a new one.
+//
+// Returns false without changing anything if names and sources differ in length,
+// or an index is out of range or listed more than once.
+func (a *Attempts) RemapSegments(names []string, sources [][]int) bool {
+	if len(names) != len(sources) || !validSources(sources, len(a.Segments)) {
+		return false
+	}
+
+	oldN := len(a.Segments)
+
+	deleted := make([]bool, oldN)
+	for i := range deleted {
+		deleted[i] = true
+	}
+
+	for _, src := range sources {
+		for _, i := range src {
+			deleted[i] = false
+		}
+	}
+
+	newIndex := remapIndices(sources, deleted)
+
+	for h := range a.History {
+		att := &a.History[h]
+		att.SplitTimesMS = remapSplits(att.SplitTimesMS, sources, deleted, att.Completed)
+
+		if att.EndedSegment >= 0 && att.EndedSegment < oldN {
+			att.EndedSegment = newIndex[att.EndedSegment]
+		}
+
+		if att.StartSegment > 0 && att.StartSegment < oldN {
+			att.StartSegment = newIndex[att.StartSegment]
+		}
+
+		att.Hits = remapHits(att.Hits, newIndex, len(sources))
+	}
+
+	for g := range a.Ghosts {
+		ghost := &a.Ghosts[g]
+		ghost.SplitTimesMS = remapSplits(ghost.SplitTimesMS, sources, deleted, len(ghost.SplitTimesMS) == oldN)
+	}
+
+	a.BaselineGolds = remapBaselineGolds(a.BaselineGolds, sources, newIndex, 0)
+	a.BaselineHits = remapBaselineGolds(a.BaselineHits, sources, newIndex, -1)
+	a.Segments = remapGroups(a.Segments, names, sources)
+	a.EnsureSegmentIDs()
+	a.UpdatedAt = time.Now()
+
+	return true
+}
+
+// SuggestSegmentSources proposes a mapping for RemapSegments by matching
+// segment names in order. New names without a match become insertions, and old
+// names without a match are deleted.
+func SuggestSegmentSources(oldNames, newNames []string) [][]int {
+	used := make([]bool, len(oldNames))
+	sources := make([][]int, len(newNames))
+
+	for j, name := range newNames {
+		for i, old := range oldNames {
+			if !used[i] && old == name {
+				used[i] = true
+				sources[j] = []int{i}
+
+				break
+			}
+		}
+	}
+
+	return sources
+}
+
+// remapGroups builds the new segment list. Kept and merged segments keep the ID
+// and group of their first source and keep their notes; an inserted segment joins a
+// group only when the segments on both sides of it belong to that group.
+func remapGroups(old []Segment, names []string, sources [][]int) []Segment {
+	segs := make([]Segment, len(names))
+
+	for j, n := range names {
+		segs[j] = Segment{Name: n}
+		if len(sources[j]) > 0 {
+			segs[j].ID = old[sources[j][0]].ID
+			segs[j].Group = old[sources[j][0]].Group
+		}
+
+		var notes []string
+
+		for _, i := range sources[j] {
+			if old[i].Notes != "" {
+				notes = append(notes, old[i].Notes)
+			}
+		}
+
+		segs[j].Notes = strings.Join(notes, "\n\n")
+	}
+
+	for j := range segs {
+		if len(sources[j]) > 0 || j == 0 {
+			continue
+		}
+
+		next := slices.IndexFunc(sources[j+1:], func(src []int) bool { return len(src) > 0 })
+		if next >= 0 && segs[j-1].Group == segs[j+1+next].Group {
+			segs[j].Group = segs[j-1].Group
+		}
+	}
+
+	return segs
+}
+
+// remapBaselineGolds carries baseline golds, times or hits, to the new layout.
+// Only a segment that absorbs exactly one old segment keeps its gold: the sum
+// of several golds was never achieved in a single attempt. Other segments get none.
+func remapBaselineGolds[T int | int64](golds []T, sources [][]int, newIndex []int, none T) []T {
+	if golds == nil {
+		return nil
+	}
+
+	absorbed := make([]int, len(sources))
+	for _, j := range newIndex {
+		if j < len(absorbed) {
+			absorbed[j]++
+		}
+	}
+
+	out := make([]T, len(sources))
+
+	for j, src := range sources {
+		out[j] = none
+		if len(src) == 1 && absorbed[j] == 1 && src[0] < len(golds) {
+			out[j] = golds[src[0]]
+		}
+	}
+
+	return out
+}
+
+// remapHits carries per-segment hits to the new layout. Hits add up, so merged
+// and deleted segments simply give theirs to the segment that absorbs them.
+func remapHits(hits []int, newIndex []int, n int) []int {
+	if hits == nil {
+		return nil
+	}
+
+	out := make([]int, n)
+
+	for i, h := range hits {
+		if i < len(newIndex) && newIndex[i] < n {
+			out[newIndex[i]] += h
+		}
+	}
+
+	return out
+}
+
+func validSources(sources [][]int, oldN int) bool {
+	seen := make([]bool, oldN)
+
+	for _, src := range sources {
+		for _, i := range src {
+			if i < 0 || i >= oldN || seen[i] {
+				return false
+			}
+
+			seen[i] = true
+		}
+	}
+
+	return true
+}
+
+// remapIndices maps each current segment index to the new segment that absorbs it.
+// A deleted segment maps to the new segment holding the next kept index.
+func remapIndices(sources [][]int, deleted []bool) []int {
+	newIndex := make([]int, len(deleted))
+
+	for j, src := range sources {
+		for _, i := range src {
+			newIndex[i] = j
+		}
+	}
+
+	next := max(len(sources)-1, 0)
+
+	for i := len(deleted) - 1; i >= 0; i-- {
+		if deleted[i] {
+			newIndex[i] = next
+		} else {
+			next = newIndex[i]
+		}
+	}
+
+	return newIndex
+}
+
+// remapSplits rewrites one attempt's cumulative splits for the new layout.
+//
+// While the new segments so far cover a prefix of the old ones (plus deleted
+// segments), the new cumulative is read directly from the old one, which keeps
+// skipped splits harmless. Once segments are reordered, the cumulative is rebuilt
+// from individual segment times, and any unknown segment makes it unknown (0).
+//
+// The result is cut after the last segment the attempt reached. Completed
+// attempts are padded to the full length so they stay complete.
+func remapSplits(splits []int64, sources [][]int, deleted []bool, completed bool) []int64 {
+	out := make([]int64, len(sources))
+	covered := make([]bool, len(deleted))
+	prefixMax := -1
+	length := 0
+
+	for j, src := range sources {
+		if len(src) == 0 {
+			continue
+		}
+
+		for _, i := range src {
+			covered[i] = true
+		}
+
+		prefixMax = max(prefixMax, slices.Max(src))
+		if prefixMax >= len(splits) {
+			continue
+		}
+
+		length = j + 1
+
+		if prefixAligned(covered, deleted, prefixMax) {
+			out[j] = splits[prefixMax]
+
+			continue
+		}
+
+		out[j] = sumSegmentTimes(splits, covered, deleted, prefixMax)
+	}
+
+	if completed {
+		length = len(sources)
+	}
+
+	return out[:length]
+}
+
+// prefixAligned reports whether every old index up to upTo is either covered or deleted.
+func prefixAligned(covered, deleted []bool, upTo int) bool {
+	for i := 0; i <= upTo; i++ {
+		if !covered[i] && !deleted[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// sumSegmentTimes adds the individual times of every covered segment and every
+// deleted segment before upTo. Returns 0 if any of them is unknown.
+func sumSegmentTimes(splits []int64, covered, deleted []bool, upTo int) int64 {
+	var sum int64
+
+	for i := 0; i <= upTo; i++ {
+		if !covered[i] && !deleted[i] {
+			continue
+		}
+
+		if splits[i] == 0 || (i > 0 && splits[i-1] == 0) {
+			return 0
+		}
+
+		segTime := splits[i]
+		if i > 0 {
+			segTime -= splits[i-1]
+		}
+
+		if segTime <= 0 {
+			return 0
+		}
+
+		sum += segTime
+	}
+
+	return sum
+}
+
+// InsertSegment adds a new segment at index with no recorded times.
+// RemoveSegment reverses it.
+func (a *Attempts) InsertSegment(index int, name string) bool {
+	if index < 0 || index > len(a.Segments) {
+		return false
+	}
+
+	names := slices.Insert(a.SegmentNames(), index, name)
+	sources := slices.Insert(identitySources(len(a.Segments)), index, []int(nil))
+
+	return a.RemapSegments(names, sources)
+}
+
+// RemoveSegment deletes the segment at index. Its time folds into the following
+// segment, or is dropped if it was the last one. A category's only segment
+// cannot be removed.
+func (a *Attempts) RemoveSegment(index int) bool {
+	if index < 0 || index >= len(a.Segments) || len(a.Segments) == 1 {
+		return false
+	}
+
+	names := slices.Delete(a.SegmentNames(), index, index+1)
+	sources := slices.Delete(identitySources(len(a.Segments)), index, index+1)
+
+	return a.RemapSegments(names, sources)
+}
+
+// MergeSegments joins the segment at index with the one after it under name.
+// Merged times are the sum of both segments. SplitSegment reverses it, although
+// the boundary between the two halves is no longer known.
+func (a *Attempts) MergeSegments(index int, name string) bool {
+	if index < 0 || index >= len(a.Segments)-1 {
+		return false
+	}
+
+	names := a.SegmentNames()
+	names[index] = name
+	names = slices.Delete(names, index+1, index+2)
+
+	sources := identitySources(len(a.Segments))
+	sources[index] = []int{index, index + 1}
+	sources = slices.Delete(sources, index+1, index+2)
+
+	return a.RemapSegments(names, sources)
+}
+
+// SplitSegment divides the segment at index into two. The new boundary is unknown
+// for every existing attempt, so the first half has no times and the second half
+// keeps the original split, the same as a skipped split. MergeSegments reverses it.
+func (a *Attempts) SplitSegment(index int, firstName, secondName string) bool {
+	if index < 0 || index >= len(a.Segments) {
+		return false
+	}
+
+	names := a.SegmentNames()
+	names[index] = secondName
+	names = slices.Insert(names, index, firstName)
+
+	sources := slices.Insert(identitySources(len(a.Segments)), index, []int(nil))
+
+	return a.RemapSegments(names, sources)
+}
+
+// MoveSegment moves the segment at from to position to, rebuilding cumulative
+// times from individual segment times. Moving it back does not always reverse
+// it: a segment without a known time, such as one after a skipped split, loses
+// the splits that followed it. Use the App-level UndoSegmentEdit to restore them.
+func (a *Attempts) MoveSegment(from, to int) bool {
+	n := len(a.Segments)
+	if from < 0 || from >= n || to < 0 || to >= n {
+		return false
+	}
+
+	names := a.SegmentNames()
+	name := names[from]
+	names = slices.Insert(slices.Delete(names, from, from+1), to, name)
+
+	sources := identitySources(n)
+	src := sources[from]
+	sources = slices.Insert(slices.Delete(sources, from, from+1), to, src)
+
+	return a.RemapSegments(names, sources)
+}
+
+func identitySources(n int) [][]int {
+	sources := make([][]int, n)
+	for i := range sources {
+		sources[i] = []int{i}
+	}
+
+	return sources
+}
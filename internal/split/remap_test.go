@@ -0,0 +1,272 @@
+package split
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRemapSegmentsRenameAndInsert(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.AddAttempt([]int64{1000, 3000, 6000}, true)
+	att.AddAttempt([]int64{900, 2500}, false)
+
+	ok := att.RemapSegments([]string{"A", "New", "B2", "C"}, [][]int{{0}, {}, {1}, {2}})
+	if !ok {
+		t.Fatal("expected remap to succeed")
+	}
+
+	if names := att.SegmentNames(); !slices.Equal(names, []string{"A", "New", "B2", "C"}) {
+		t.Fatalf("unexpected segment names: %v", names)
+	}
+
+	if got := att.History[0].SplitTimesMS; !slices.Equal(got, []int64{1000, 0, 3000, 6000}) {
+		t.Fatalf("unexpected completed splits: %v", got)
+	}
+
+	if got := att.History[1].SplitTimesMS; !slices.Equal(got, []int64{900, 0, 2500}) {
+		t.Fatalf("unexpected incomplete splits: %v", got)
+	}
+
+	if pb := att.PersonalBestSplits(); pb == nil || pb[3] != 6000 {
+		t.Fatalf("expected PB to survive, got %v", pb)
+	}
+
+	// The gold on A survives; the inserted segment has no data yet.
+	best := att.BestSegments()
+	if best[0] != 900 || best[1] != 0 {
+		t.Fatalf("unexpected best segments: %v", best)
+	}
+}
+
+func TestRemapSegmentsDelete(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.AddAttempt([]int64{1000, 3000, 6000}, true)
+	att.AddAttempt([]int64{900, 2500}, false)
+
+	// Deleting B folds its time into C.
+	if !att.RemapSegments([]string{"A", "C"}, [][]int{{0}, {2}}) {
+		t.Fatal("expected remap to succeed")
+	}
+
+	if got := att.History[0].SplitTimesMS; !slices.Equal(got, []int64{1000, 6000}) {
+		t.Fatalf("unexpected completed splits: %v", got)
+	}
+
+	// The incomplete run never reached C, so only A is kept.
+	if got := att.History[1].SplitTimesMS; !slices.Equal(got, []int64{900}) {
+		t.Fatalf("unexpected incomplete splits: %v", got)
+	}
+
+	if att.History[1].EndedSegment != 1 {
+		t.Fatalf("expected reset on deleted B to map onto C, got %d", att.History[1].EndedSegment)
+	}
+}
+
+func TestRemapSegmentsMerge(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.AddAttempt([]int64{1000, 3000, 6000}, true)
+	att.AddAttempt([]int64{900, 2500}, false)
+
+	if !att.RemapSegments([]string{"AB", "C"}, [][]int{{0, 1}, {2}}) {
+		t.Fatal("expected remap to succeed")
+	}
+
+	if got := att.History[0].SplitTimesMS; !slices.Equal(got, []int64{3000, 6000}) {
+		t.Fatalf("unexpected completed splits: %v", got)
+	}
+
+	if got := att.History[1].SplitTimesMS; !slices.Equal(got, []int64{2500}) {
+		t.Fatalf("unexpected incomplete splits: %v", got)
+	}
+
+	// The merged gold is the best combined time.
+	if best := att.BestSegments(); best[0] != 2500 || best[1] != 3000 {
+		t.Fatalf("unexpected best segments: %v", att.BestSegments())
+	}
+}
+
+func TestRemapSegmentsReorder(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.AddAttempt([]int64{1000, 3000, 6000}, true)
+	att.AddAttempt([]int64{900, 2500}, false)
+
+	if !att.RemapSegments([]string{"B", "A", "C"}, [][]int{{1}, {0}, {2}}) {
+		t.Fatal("expected remap to succeed")
+	}
+
+	// B took 2000, A took 1000.
+	if got := att.History[0].SplitTimesMS; !slices.Equal(got, []int64{2000, 3000, 6000}) {
+		t.Fatalf("unexpected completed splits: %v", got)
+	}
+
+	if best := att.BestSegments(); best[0] != 1600 || best[1] != 900 || best[2] != 3000 {
+		t.Fatalf("unexpected best segments: %v", best)
+	}
+}
+
+func TestRemapSegmentsReorderWithSkip(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.AddAttempt([]int64{0, 3000, 6000}, true)
+
+	if !att.RemapSegments([]string{"B", "A", "C"}, [][]int{{1}, {0}, {2}}) {
+		t.Fatal("expected remap to succeed")
+	}
+
+	// B's own time is unknown after a skipped A, but the cumulative through C is not.
+	if got := att.History[0].SplitTimesMS; !slices.Equal(got, []int64{0, 3000, 6000}) {
+		t.Fatalf("unexpected splits: %v", got)
+	}
+}
+
+func TestRemapSegmentsRejectsInvalid(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.AddAttempt([]int64{1000, 3000, 6000}, true)
+	att.AddAttempt([]int64{900, 2500}, false)
+
+	cases := []struct {
+		name    string
+		names   []string
+		sources [][]int
+	}{
+		{name: "length mismatch", names: []string{"A"}, sources: [][]int{{0}, {1}}},
+		{name: "out of range", names: []string{"A"}, sources: [][]int{{3}}},
+		{name: "negative", names: []string{"A"}, sources: [][]int{{-1}}},
+		{name: "duplicate", names: []string{"A", "B"}, sources: [][]int{{0}, {0}}},
+	}
+
+	for _, tc := range cases {
+		if att.RemapSegments(tc.names, tc.sources) {
+			t.Fatalf("%s: expected remap to be rejected", tc.name)
+		}
+	}
+
+	if len(att.Segments) != 3 || len(att.History[0].SplitTimesMS) != 3 {
+		t.Fatal("expected rejected remaps to leave attempts unchanged")
+	}
+}
+
+func TestSuggestSegmentSources(t *testing.T) {
+	got := SuggestSegmentSources([]string{"A", "B", "C", "B"}, []string{"B", "New", "C", "B"})
+	want := [][]int{{1}, nil, {2}, {3}}
+
+	if !slices.EqualFunc(got, want, slices.Equal[[]int]) {
+		t.Fatalf("SuggestSegmentSources = %v, want %v", got, want)
+	}
+}
+
+func TestInsertAndRemoveSegment(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.AddAttempt([]int64{1000, 3000, 6000}, true)
+	att.AddAttempt([]int64{900, 2500}, false)
+
+	if !att.InsertSegment(1, "New") {
+		t.Fatal("expected insert to succeed")
+	}
+
+	if got := att.History[0].SplitTimesMS; !slices.Equal(got, []int64{1000, 0, 3000, 6000}) {
+		t.Fatalf("unexpected splits after insert: %v", got)
+	}
+
+	if !att.RemoveSegment(1) {
+		t.Fatal("expected remove to succeed")
+	}
+
+	if got := att.History[0].SplitTimesMS; !slices.Equal(got, []int64{1000, 3000, 6000}) {
+		t.Fatalf("expected remove to reverse insert, got %v", got)
+	}
+
+	if names := att.SegmentNames(); !slices.Equal(names, []string{"A", "B", "C"}) {
+		t.Fatalf("unexpected names: %v", names)
+	}
+
+	if att.InsertSegment(4, "X") || att.RemoveSegment(3) {
+		t.Fatal("expected out-of-range edits to fail")
+	}
+}
+
+func TestMergeAndSplitSegment(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.AddAttempt([]int64{1000, 3000, 6000}, true)
+	att.AddAttempt([]int64{900, 2500}, false)
+
+	if !att.SplitSegment(1, "B1", "B2") {
+		t.Fatal("expected split to succeed")
+	}
+
+	if names := att.SegmentNames(); !slices.Equal(names, []string{"A", "B1", "B2", "C"}) {
+		t.Fatalf("unexpected names: %v", names)
+	}
+
+	// The boundary inside B is unknown.
+	if got := att.History[0].SplitTimesMS; !slices.Equal(got, []int64{1000, 0, 3000, 6000}) {
+		t.Fatalf("unexpected splits after split: %v", got)
+	}
+
+	if pb := att.PersonalBestSplits(); pb == nil || pb[3] != 6000 {
+		t.Fatalf("expected PB to stay valid, got %v", pb)
+	}
+
+	if !att.MergeSegments(1, "B") {
+		t.Fatal("expected merge to succeed")
+	}
+
+	if got := att.History[0].SplitTimesMS; !slices.Equal(got, []int64{1000, 3000, 6000}) {
+		t.Fatalf("expected merge to reverse split, got %v", got)
+	}
+
+	if att.MergeSegments(2, "X") {
+		t.Fatal("expected merging the last segment to fail")
+	}
+}
+
+func TestMoveSegmentRoundTrip(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.AddAttempt([]int64{1000, 3000, 6000}, true)
+	att.AddAttempt([]int64{900, 2500}, false)
+
+	if !att.MoveSegment(0, 2) {
+		t.Fatal("expected move to succeed")
+	}
+
+	if names := att.SegmentNames(); !slices.Equal(names, []string{"B", "C", "A"}) {
+		t.Fatalf("unexpected names: %v", names)
+	}
+
+	if got := att.History[0].SplitTimesMS; !slices.Equal(got, []int64{2000, 5000, 6000}) {
+		t.Fatalf("unexpected splits after move: %v", got)
+	}
+
+	if pb := att.PersonalBestSplits(); pb == nil || pb[2] != 6000 {
+		t.Fatalf("expected PB final to be unchanged, got %v", pb)
+	}
+
+	if !att.MoveSegment(2, 0) {
+		t.Fatal("expected move back to succeed")
+	}
+
+	if got := att.History[0].SplitTimesMS; !slices.Equal(got, []int64{1000, 3000, 6000}) {
+		t.Fatalf("expected move back to reverse, got %v", got)
+	}
+
+	// The reset run never reached A's new position at the end, so its times
+	// could not be placed and do not come back.
+	if best := att.BestSegments(); best[0] != 1000 || best[1] != 2000 || best[2] != 3000 {
+		t.Fatalf("unexpected best segments after round trip: %v", best)
+	}
+}
+
+func TestMergeSegmentsJoinsNotes(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.AddAttempt([]int64{1000, 3000, 6000}, true)
+	att.AddAttempt([]int64{900, 2500}, false)
+	att.SetSegmentNotes(0, "first")
+	att.SetSegmentNotes(1, "second")
+
+	if !att.MergeSegments(0, "AB") {
+		t.Fatal("expected merge to succeed")
+	}
+
+	if got := att.Segments[0].Notes; got != "first\n\nsecond" {
+		t.Fatalf("unexpected merged notes: %q", got)
+	}
+}
@@ -0,0 +1,94 @@
+package split
+
+import "time"
+
+// ResetPolicy decides what an incomplete run contributes when it is reset.
+type ResetPolicy string
+
+const (
+	ResetSave      ResetPolicy = "save"    // Record the attempt.
+	ResetGoldsOnly ResetPolicy = "golds"   // Keep any golds as BaselineGolds and BaselineHits, without the attempt.
+	ResetDiscard   ResetPolicy = "discard" // Drop the run entirely, e.g. after a controller disconnect.
+)
+
+// Valid reports whether p is a known policy.
+func (p ResetPolicy) Valid() bool {
+	switch p {
+	case ResetSave, ResetGoldsOnly, ResetDiscard:
+		return true
+	default:
+		return false
+	}
+}
+
+// ResetPolicyOrDefault returns the category's reset policy, saving the attempt if none is set.
+func (a *Attempts) ResetPolicyOrDefault() ResetPolicy {
+	if a.ResetPolicy == "" {
+		return ResetSave
+	}
+
+	return a.ResetPolicy
+}
+
+// SetResetPolicy sets the category's default reset policy. An empty policy
+// restores the default. Returns false for an unknown policy.
+func (a *Attempts) SetResetPolicy(p ResetPolicy) bool {
+	if p != "" && !p.Valid() {
+		return false
+	}
+
+	a.ResetPolicy = p
+	a.UpdatedAt = time.Now()
+
+	return true
+}
+
+// KeepGolds merges the segment times of an unrecorded run into BaselineGolds,
+// and its hits into BaselineHits, where they beat the current golds. hits is
+// nil for a timed run. Segments before startSegment were not run and are
+// ignored. Returns the number of golds kept; AttemptCount is untouched.
+func (a *Attempts) KeepGolds(splitTimesMS []int64, hits []int, startSegment int) int {
+	best := a.BestSegments()
+	bestHits := a.BestSegmentHits()
+	run := Attempt{SplitTimesMS: splitTimesMS, StartSegment: startSegment}
+	kept := 0
+
+	for i := range splitTimesMS {
+		if i >= len(a.Segments) {
+			break
+		}
+
+		segTime, ok := run.SegmentTimeMS(i)
+		if !ok || (best[i] != 0 && segTime >= best[i]) {
+			continue
+		}
+
+		if len(a.BaselineGolds) < len(a.Segments) {
+			golds := make([]int64, len(a.Segments))
+			copy(golds, a.BaselineGolds)
+			a.BaselineGolds = golds
+		}
+
+		a.BaselineGolds[i] = segTime
+		kept++
+	}
+
+	for i := startSegment; i < len(splitTimesMS) && i < len(hits) && i < len(a.Segments); i++ {
+		if splitTimesMS[i] == 0 || (bestHits[i] >= 0 && hits[i] >= bestHits[i]) {
+			continue
+		}
+
+		for len(a.BaselineHits) < len(a.Segments) {
+			a.BaselineHits = append(a.BaselineHits, -1)
+		}
+
+		a.BaselineHits[i] = hits[i]
+		kept++
+	}
+
+	if kept > 0 {
+		a.UpdatedAt = time.Now()
+	}
+
+	return kept
+}
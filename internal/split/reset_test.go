@@ -0,0 +1,66 @@
+package split
+
+import "testing"
+
+func TestKeepGolds(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.AddAttempt([]int64{1000, 2000, 3000}, true)
+
+	// A reset run with a faster B, kept for its golds only.
+	if kept := att.KeepGolds([]int64{1100, 1900}, nil, 0); kept != 1 {
+		t.Fatalf("expected 1 gold kept, got %d", kept)
+	}
+
+	if att.AttemptCount != 1 || len(att.History) != 1 {
+		t.Fatalf("golds-only reset must not add an attempt, count=%d", att.AttemptCount)
+	}
+
+	best := att.BestSegments()
+	if best[0] != 1000 || best[1] != 800 || best[2] != 1000 {
+		t.Fatalf("unexpected golds: %v", best)
+	}
+
+	// Preloaded segments of a partial start are never golds.
+	if kept := att.KeepGolds([]int64{1, 5000}, nil, 1); kept != 0 {
+		t.Fatalf("expected no golds from preloaded segments, got %d", kept)
+	}
+}
+
+func TestKeepGoldsHits(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.SetHitCounting(true)
+	att.RecordAttempt([]int64{1000, 2000, 3000}, true, AttemptEnd{Reason: EndFinished, Segment: 2, Hits: []int{2, 1, 3}})
+
+	// Fewer hits in A and C, but C was never split.
+	if kept := att.KeepGolds([]int64{1200, 2500}, []int{0, 1, 0}, 0); kept != 1 {
+		t.Fatalf("expected 1 hit gold kept, got %d", kept)
+	}
+
+	best := att.BestSegmentHits()
+	if best[0] != 0 || best[1] != 1 || best[2] != 3 {
+		t.Fatalf("unexpected hit golds: %v", best)
+	}
+
+	// A skipped segment is no hit gold.
+	if kept := att.KeepGolds([]int64{1300, 0}, []int{1, 0}, 0); kept != 0 {
+		t.Fatalf("expected no golds from a skipped segment, got %d", kept)
+	}
+}
+
+func TestSetResetPolicy(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A"})
+
+	if att.ResetPolicyOrDefault() != ResetSave {
+		t.Fatalf("expected save by default, got %q", att.ResetPolicyOrDefault())
+	}
+
+	if att.SetResetPolicy("bogus") {
+		t.Fatal("expected unknown policy to be rejected")
+	}
+
+	att.SetResetPolicy(ResetDiscard)
+
+	if att.ResetPolicyOrDefault() != ResetDiscard {
+		t.Fatalf("expected discard, got %q", att.ResetPolicyOrDefault())
+	}
+}
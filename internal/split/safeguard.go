@@ -0,0 +1,112 @@
+package split
+
+import (
+	"slices"
+	"time"
+)
+
+// ResetSafeguards guards against resetting a run worth keeping.
+type ResetSafeguards struct {
+	WhenAheadOfPB       bool   `json:"whenAheadOfPb"`       // Guard resets while ahead of PB.
+	AfterGold           bool   `json:"afterGold"`           // Guard resets once the run has a gold.
+	Mode                string `json:"mode"`                // "confirm" asks in a dialog, "double_press" needs a second reset.
+	DoublePressWindowMS int    `json:"doublePressWindowMs"` // How soon the second reset must follow the first.
+	IgnoreAfterSplitMS  int    `json:"ignoreAfterSplitMs"`  // Ignore resets this soon after a split; 0 disables.
+}
+
+// Reasons a reset is guarded.
+const (
+	RiskAheadOfPB   = "ahead_of_pb"  // The run is ahead of PB.
+	RiskGold        = "gold"         // The run has a gold.
+	RiskRecentSplit = "recent_split" // The reset came right after a split.
+)
+
+// Reset safeguard decisions. Only a confirmed reset goes ahead.
+const (
+	ResetIgnored   = "ignored"   // Too soon after a split.
+	ResetArmed     = "armed"     // First press of a double press.
+	ResetConfirmed = "confirmed" // Second press in time, or confirmed in the dialog.
+	ResetCancelled = "cancelled" // Declined in the dialog.
+)
+
+// ResetCheck is a reset of a run in progress, as seen by the safeguards.
+type ResetCheck struct {
+	Risk        string // From RunRisk; "" if the run is not worth guarding.
+	Now         time.Time
+	LastSplitAt time.Time // Zero if the run has not split yet.
+	ArmedAt     time.Time // First press of a pending double press; zero if none.
+}
+
+// RunRisk returns why a run in progress is worth guarding: RiskAheadOfPB,
+// RiskGold, or "" if no enabled safeguard applies. Hit-counted categories
+// compare hits instead of times. Segments before startSegment were preloaded,
+// and a run that cannot set golds is never guarded for having one.
+func (g ResetSafeguards) RunRisk(att *Attempts, splits []int64, hits []int, startSegment int, canSetGolds bool) string {
+	if att == nil || (!g.WhenAheadOfPB && !g.AfterGold) {
+		return ""
+	}
+
+	var ahead, gold bool
+
+	if att.HitCounting {
+		deltas := ComputeHitDeltas(att, hits, len(splits), "personal_best")
+		deltas = slices.DeleteFunc(deltas, func(d HitDelta) bool { return d.SegmentIndex < startSegment })
+
+		ahead = len(deltas) > 0 && deltas[len(deltas)-1].IsAhead
+		gold = slices.ContainsFunc(deltas, func(d HitDelta) bool { return d.IsBestEver && splits[d.SegmentIndex] != 0 })
+	} else {
+		deltas := ComputeSplitDeltas(att, splits, "personal_best")
+		deltas = slices.DeleteFunc(deltas, func(d Delta) bool { return d.SegmentIndex < startSegment })
+
+		for _, d := range slices.Backward(deltas) {
+			if d.Skipped || d.DeltaMS == 0 {
+				continue
+			}
+
+			ahead = d.IsAhead
+
+			break
+		}
+
+		gold = slices.ContainsFunc(deltas, func(d Delta) bool { return d.IsBestEver })
+	}
+
+	switch {
+	case g.WhenAheadOfPB && ahead:
+		return RiskAheadOfPB
+	case g.AfterGold && canSetGolds && gold:
+		return RiskGold
+	default:
+		return ""
+	}
+}
+
+// Decide applies the safeguards to a reset. It returns the decision and its
+// reason, or empty strings if no safeguard applies and the reset goes ahead,
+// along with the new first-press time of a double press. confirm asks the
+// runner in "confirm" mode.
+func (g ResetSafeguards) Decide(c ResetCheck, confirm func(risk string) bool) (decision, reason string, armedAt time.Time) {
+	if g.IgnoreAfterSplitMS > 0 && !c.LastSplitAt.IsZero() &&
+		c.Now.Sub(c.LastSplitAt) < time.Duration(g.IgnoreAfterSplitMS)*time.Millisecond {
+		return ResetIgnored, RiskRecentSplit, c.ArmedAt
+	}
+
+	if c.Risk == "" {
+		return "", "", c.ArmedAt
+	}
+
+	switch g.Mode {
+	case "double_press":
+		if !c.ArmedAt.IsZero() && c.Now.Sub(c.ArmedAt) < time.Duration(g.DoublePressWindowMS)*time.Millisecond {
+			return ResetConfirmed, c.Risk, time.Time{}
+		}
+
+		return ResetArmed, c.Risk, c.Now
+	default:
+		if !confirm(c.Risk) {
+			return ResetCancelled, c.Risk, time.Time{}
+		}
+
+		return ResetConfirmed, c.Risk, time.Time{}
+	}
+}
@@ -0,0 +1,100 @@
+package split
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRunRisk(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.RecordAttempt([]int64{1000, 2000, 3000}, true, AttemptEnd{Reason: EndFinished, Segment: 2})
+
+	ahead := ResetSafeguards{WhenAheadOfPB: true}
+	gold := ResetSafeguards{AfterGold: true}
+
+	if r := ahead.RunRisk(att, []int64{900}, nil, 0, true); r != RiskAheadOfPB {
+		t.Fatalf("expected ahead of PB, got %q", r)
+	}
+
+	if r := gold.RunRisk(att, []int64{900}, nil, 0, true); r != RiskGold {
+		t.Fatalf("expected gold, got %q", r)
+	}
+
+	if r := ahead.RunRisk(att, []int64{1100}, nil, 0, true); r != "" {
+		t.Fatalf("expected no risk behind PB, got %q", r)
+	}
+
+	// Started at B with A preloaded: B's gold cannot be kept.
+	if r := gold.RunRisk(att, []int64{1000, 1900}, nil, 1, false); r != "" {
+		t.Fatalf("expected no gold risk for a run that cannot set golds, got %q", r)
+	}
+}
+
+func TestRunRiskCountsHits(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "No-hit", []string{"A", "B"})
+	att.SetHitCounting(true)
+	att.RecordAttempt([]int64{1000, 2000}, true, AttemptEnd{Reason: EndFinished, Segment: 1, Hits: []int{1, 1}})
+
+	guard := ResetSafeguards{WhenAheadOfPB: true}
+
+	// Slower than the PB, but with fewer hits.
+	if r := guard.RunRisk(att, []int64{1500}, []int{0, 0}, 0, true); r != RiskAheadOfPB {
+		t.Fatalf("expected ahead of PB on hits, got %q", r)
+	}
+
+	if r := guard.RunRisk(att, []int64{500}, []int{2, 0}, 0, true); r != "" {
+		t.Fatalf("expected no risk with more hits, got %q", r)
+	}
+
+	// Skipping a segment takes no hits there, but sets no gold either.
+	guard = ResetSafeguards{AfterGold: true}
+	if r := guard.RunRisk(att, []int64{0}, []int{0, 0}, 0, true); r != "" {
+		t.Fatalf("expected no gold risk from a skipped segment, got %q", r)
+	}
+}
+
+func TestResetDecide(t *testing.T) {
+	now := time.Now()
+	never := func(string) bool {
+		t.Fatal("unexpected confirmation dialog")
+
+		return false
+	}
+
+	guard := ResetSafeguards{Mode: "double_press", DoublePressWindowMS: 2000, IgnoreAfterSplitMS: 500}
+
+	if d, _, _ := guard.Decide(ResetCheck{Now: now}, never); d != "" {
+		t.Fatalf("expected an unguarded reset to go ahead, got %q", d)
+	}
+
+	recent := ResetCheck{Now: now, LastSplitAt: now.Add(-200 * time.Millisecond)}
+	if d, reason, _ := guard.Decide(recent, never); d != ResetIgnored || reason != RiskRecentSplit {
+		t.Fatalf("expected a reset right after a split to be ignored, got %q (%s)", d, reason)
+	}
+
+	d, _, armedAt := guard.Decide(ResetCheck{Risk: RiskGold, Now: now}, never)
+	if d != ResetArmed || !armedAt.Equal(now) {
+		t.Fatalf("expected the first press to arm, got %q at %v", d, armedAt)
+	}
+
+	second := ResetCheck{Risk: RiskGold, Now: now.Add(time.Second), ArmedAt: armedAt}
+	if d, _, armedAt := guard.Decide(second, never); d != ResetConfirmed || !armedAt.IsZero() {
+		t.Fatalf("expected the second press to confirm, got %q", d)
+	}
+
+	late := ResetCheck{Risk: RiskGold, Now: now.Add(3 * time.Second), ArmedAt: armedAt}
+	if d, _, _ := guard.Decide(late, never); d != ResetArmed {
+		t.Fatalf("expected a late second press to arm again, got %q", d)
+	}
+
+	confirm := ResetSafeguards{Mode: "confirm"}
+	check := ResetCheck{Risk: RiskAheadOfPB, Now: now}
+
+	if d, _, _ := confirm.Decide(check, func(string) bool { return false }); d != ResetCancelled {
+		t.Fatalf("expected a declined dialog to cancel, got %q", d)
+	}
+
+	if d, reason, _ := confirm.Decide(check, func(string) bool { return true }); d != ResetConfirmed || reason != RiskAheadOfPB {
+		t.Fatalf("expected an accepted dialog to confirm, got %q (%s)", d, reason)
+	}
+}
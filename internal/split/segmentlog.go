@@ -0,0 +1,71 @@
+package split
+
+import (
+	"slices"
+	"time"
+)
+
+// SegmentRun is one timed run of a single segment outside a full attempt.
+// Runs are keyed by segment ID so they survive renames, moves and other
+// segment edits, and segments sharing a name are kept apart.
+type SegmentRun struct {
+	SegmentID  int       `json:"segmentId"`
+	TimeMS     int64     `json:"timeMs"`
+	RecordedAt time.Time `json:"recordedAt"`
+}
+
+// SegmentLog holds single-segment runs of an attempts entry, such as IL or
+// practice runs. It is kept apart from History, so its runs never change
+// AttemptCount, PB or golds.
+type SegmentLog struct {
+	AttemptsID string       `json:"attemptsId"`
+	Runs       []SegmentRun `json:"runs"`
+}
+
+// NewSegmentLog creates an empty segment log for an attempts entry.
+func NewSegmentLog(attemptsID string) *SegmentLog {
+	return &SegmentLog{AttemptsID: attemptsID}
+}
+
+// Best returns the fastest run of the segment with the given ID.
+func (l *SegmentLog) Best(segmentID int) (SegmentRun, bool) {
+	runs := l.runsOf(segmentID)
+	if len(runs) == 0 {
+		return SegmentRun{}, false
+	}
+
+	return slices.MinFunc(runs, compareSegmentRuns), true
+}
+
+func (l *SegmentLog) runsOf(segmentID int) []SegmentRun {
+	var runs []SegmentRun
+
+	for _, run := range l.Runs {
+		if run.SegmentID == segmentID {
+			runs = append(runs, run)
+		}
+	}
+
+	return runs
+}
+
+func (l *SegmentLog) timesOf(segmentID int) []int64 {
+	var times []int64
+
+	for _, run := range l.runsOf(segmentID) {
+		times = append(times, run.TimeMS)
+	}
+
+	return times
+}
+
+func compareSegmentRuns(a, b SegmentRun) int {
+	switch {
+	case a.TimeMS < b.TimeMS:
+		return -1
+	case a.TimeMS > b.TimeMS:
+		return 1
+	default:
+		return a.RecordedAt.Compare(b.RecordedAt)
+	}
+}
@@ -0,0 +1,180 @@
+package split
+
+import (
+	"slices"
+	"time"
+)
+
+// SessionSpan is a practice session started and ended explicitly by the runner.
+type SessionSpan struct {
+	ID        int       `json:"id"`
+	StartedAt time.Time `json:"startedAt"`
+	EndedAt   time.Time `json:"endedAt"` // Zero while the session is still open.
+}
+
+// contains reports whether t falls inside the session.
+func (s SessionSpan) contains(t time.Time) bool {
+	return !t.Before(s.StartedAt) && (s.EndedAt.IsZero() || t.Before(s.EndedAt))
+}
+
+// SessionStats summarizes the attempts played in one session.
+type SessionStats struct {
+	SessionID   int       `json:"sessionId"` // Explicit session ID, or 0 for sessions inferred from idle gaps.
+	StartedAt   time.Time `json:"startedAt"`
+	EndedAt     time.Time `json:"endedAt"`
+	AttemptIDs  []int     `json:"attemptIds"`
+	Attempts    int       `json:"attempts"`
+	Resets      int       `json:"resets"`
+	Completions int       `json:"completions"`
+	GoldsGained int       `json:"goldsGained"` // Segments that beat the best time set before the attempt.
+	BestRunID   int       `json:"bestRunId"`   // Fastest completed attempt, 0 if none.
+	BestRunMS   int64     `json:"bestRunMs"`
+	PlayTimeMS  int64     `json:"playTimeMs"` // Includes pause time.
+}
+
+// StartSession opens a new explicit session, closing any session still open.
+func (a *Attempts) StartSession(now time.Time) int {
+	a.EndSession(now)
+
+	id := 1
+	if len(a.Sessions) > 0 {
+		id = a.Sessions[len(a.Sessions)-1].ID + 1
+	}
+
+	a.Sessions = append(a.Sessions, SessionSpan{ID: id, StartedAt: now})
+	a.UpdatedAt = time.Now()
+
+	return id
+}
+
+// EndSession closes the open explicit session. Returns false if none is open.
+func (a *Attempts) EndSession(now time.Time) bool {
+	s := a.ActiveSession()
+	if s == nil {
+		return false
+	}
+
+	s.EndedAt = now
+	a.UpdatedAt = time.Now()
+
+	return true
+}
+
+// ActiveSession returns the open explicit session, or nil if none is open.
+func (a *Attempts) ActiveSession() *SessionSpan {
+	if len(a.Sessions) == 0 || !a.Sessions[len(a.Sessions)-1].EndedAt.IsZero() {
+		return nil
+	}
+
+	return &a.Sessions[len(a.Sessions)-1]
+}
+
+// SessionStats groups History into sessions and summarizes each, oldest first.
+// Attempts started inside an explicit session belong to it. The rest are grouped
+// automatically: a new session begins whenever more than idleGap passes between
+// one attempt ending and the next starting.
+func (a *Attempts) SessionStats(idleGap time.Duration) []SessionStats {
+	golds := a.goldsPerAttempt()
+
+	explicit := make([]SessionStats, len(a.Sessions))
+	for i, span := range a.Sessions {
+		explicit[i] = SessionStats{SessionID: span.ID, StartedAt: span.StartedAt, EndedAt: span.EndedAt}
+	}
+
+	var inferred []SessionStats
+	var current *SessionStats
+
+	for i, att := range a.History {
+		if idx := slices.IndexFunc(a.Sessions, func(s SessionSpan) bool { return s.contains(att.StartedAt) }); idx >= 0 {
+			explicit[idx].add(att, golds[i])
+			current = nil
+
+			continue
+		}
+
+		if current == nil || att.StartedAt.Sub(current.EndedAt) > idleGap {
+			inferred = append(inferred, SessionStats{StartedAt: att.StartedAt})
+			current = &inferred[len(inferred)-1]
+		}
+
+		current.add(att, golds[i])
+
+		if end := attemptEnd(att); end.After(current.EndedAt) {
+			current.EndedAt = end
+		}
+	}
+
+	sessions := append(explicit, inferred...)
+	slices.SortStableFunc(sessions, func(x, y SessionStats) int {
+		return x.StartedAt.Compare(y.StartedAt)
+	})
+
+	return sessions
+}
+
+func (s *SessionStats) add(att Attempt, golds int) {
+	s.AttemptIDs = append(s.AttemptIDs, att.ID)
+	s.Attempts++
+	s.GoldsGained += golds
+	s.PlayTimeMS += att.PlayTimeMS(true)
+
+	if att.Reason() == EndReset {
+		s.Resets++
+	}
+
+	if !att.Completed || len(att.SplitTimesMS) == 0 {
+		return
+	}
+
+	s.Completions++
+
+	if final := att.SplitTimesMS[len(att.SplitTimesMS)-1]; final > 0 && (s.BestRunID == 0 || final < s.BestRunMS) {
+		s.BestRunID = att.ID
+		s.BestRunMS = final
+	}
+}
+
+// goldsPerAttempt counts, for each attempt in History order, the segments that
+// beat the best time recorded by earlier attempts or BaselineGolds. A segment's
+// first time is not a gold.
+func (a *Attempts) goldsPerAttempt() []int {
+	best := make([]int64, len(a.Segments))
+	copy(best, a.BaselineGolds)
+	golds := make([]int, len(a.History))
+
+	for h, att := range a.History {
+		if att.ExcludeGolds {
+			continue
+		}
+
+		for i := range att.SplitTimesMS {
+			if i >= len(best) {
+				break
+			}
+
+			segTime, ok := att.SegmentTimeMS(i)
+			if !ok {
+				continue
+			}
+
+			if best[i] != 0 && segTime < best[i] {
+				golds[h]++
+			}
+
+			if best[i] == 0 || segTime < best[i] {
+				best[i] = segTime
+			}
+		}
+	}
+
+	return golds
+}
+
+// attemptEnd returns when an attempt ended, estimated from its play time if unrecorded.
+func attemptEnd(att Attempt) time.Time {
+	if !att.EndedAt.IsZero() {
+		return att.EndedAt
+	}
+
+	return att.StartedAt.Add(time.Duration(att.PlayTimeMS(true)) * time.Millisecond)
+}
@@ -0,0 +1,119 @@
+package split
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSessionStatsIdleGap(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+	day := time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)
+
+	record := func(start time.Time, splits []int64, completed bool) {
+		reason := EndReset
+		if completed {
+			reason = EndFinished
+		}
+
+		end := start.Add(time.Duration(splits[len(splits)-1]) * time.Millisecond)
+		att.RecordAttempt(splits, completed, AttemptEnd{Reason: reason, StartedAt: start, EndedAt: end})
+	}
+
+	// First evening: a reset, then two completed runs with a gold on B.
+	record(day, []int64{60000}, false)
+	record(day.Add(5*time.Minute), []int64{60000, 180000}, true)
+	record(day.Add(10*time.Minute), []int64{61000, 170000}, true)
+
+	// Next day: one more run.
+	record(day.Add(24*time.Hour), []int64{59000, 175000}, true)
+
+	sessions := att.SessionStats(30 * time.Minute)
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(sessions))
+	}
+
+	first := sessions[0]
+	if first.Attempts != 3 || first.Resets != 1 || first.Completions != 2 {
+		t.Fatalf("unexpected first session counts: %+v", first)
+	}
+
+	// Attempt 3 beats B (109000 < 120000).
+	if first.GoldsGained != 1 {
+		t.Fatalf("expected 1 gold in first session, got %d", first.GoldsGained)
+	}
+
+	if first.BestRunID != 3 || first.BestRunMS != 170000 {
+		t.Fatalf("expected best run 3 at 170000, got %d at %d", first.BestRunID, first.BestRunMS)
+	}
+
+	if first.PlayTimeMS != 60000+180000+170000 {
+		t.Fatalf("expected play time 410000, got %d", first.PlayTimeMS)
+	}
+
+	if !first.EndedAt.Equal(day.Add(10*time.Minute + 170*time.Second)) {
+		t.Fatalf("expected session to end with its last attempt, got %v", first.EndedAt)
+	}
+
+	// Attempt 4 beats A (59000 < 60000).
+	if sessions[1].Attempts != 1 || sessions[1].GoldsGained != 1 {
+		t.Fatalf("unexpected second session: %+v", sessions[1])
+	}
+}
+
+func TestSessionStatsExplicit(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A"})
+	day := time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)
+
+	att.RecordAttempt([]int64{1000}, true, AttemptEnd{Reason: EndFinished, StartedAt: day})
+
+	id := att.StartSession(day.Add(time.Minute))
+	if id != 1 || att.ActiveSession() == nil {
+		t.Fatalf("expected open session 1, got %d", id)
+	}
+
+	att.RecordAttempt([]int64{900}, true, AttemptEnd{Reason: EndFinished, StartedAt: day.Add(2 * time.Minute)})
+	att.RecordAttempt([]int64{950}, true, AttemptEnd{Reason: EndFinished, StartedAt: day.Add(3 * time.Minute)})
+
+	if !att.EndSession(day.Add(4 * time.Minute)) {
+		t.Fatal("expected open session to end")
+	}
+
+	if att.EndSession(day.Add(5 * time.Minute)) {
+		t.Fatal("expected no open session to end")
+	}
+
+	att.RecordAttempt([]int64{980}, true, AttemptEnd{Reason: EndFinished, StartedAt: day.Add(5 * time.Minute)})
+
+	sessions := att.SessionStats(time.Hour)
+	if len(sessions) != 3 {
+		t.Fatalf("expected 3 sessions, got %d", len(sessions))
+	}
+
+	if sessions[0].SessionID != 0 || sessions[0].Attempts != 1 {
+		t.Fatalf("expected inferred session with attempt 1, got %+v", sessions[0])
+	}
+
+	if sessions[1].SessionID != 1 || sessions[1].Attempts != 2 || sessions[1].BestRunID != 2 {
+		t.Fatalf("expected explicit session with attempts 2 and 3, got %+v", sessions[1])
+	}
+
+	// The explicit session splits the inferred ones even within the idle gap.
+	if sessions[2].SessionID != 0 || sessions[2].AttemptIDs[0] != 4 {
+		t.Fatalf("expected inferred session with attempt 4, got %+v", sessions[2])
+	}
+}
+
+func TestStartSessionClosesOpenSession(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A"})
+	now := time.Date(2024, 1, 1, 18, 0, 0, 0, time.UTC)
+
+	att.StartSession(now)
+
+	if id := att.StartSession(now.Add(time.Hour)); id != 2 {
+		t.Fatalf("expected session 2, got %d", id)
+	}
+
+	if !att.Sessions[0].EndedAt.Equal(now.Add(time.Hour)) {
+		t.Fatalf("expected first session closed at the second's start, got %v", att.Sessions[0].EndedAt)
+	}
+}
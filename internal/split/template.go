@@ -4,11 +4,28 @@ import "time"
 
 // Template is a reusable blueprint for a speedrun: game name and segment names.
 type Template struct {
-	ID           string    `json:"id"`
-	Name         string    `json:"name"`
-	SegmentNames []string  `json:"segmentNames"`
-	CreatedAt    time.Time `json:"createdAt"`
-	UpdatedAt    time.Time `json:"updatedAt"`
+	ID            string        `json:"id"`
+	Name          string        `json:"name"`
+	SegmentNames  []string      `json:"segmentNames"`
+	SegmentGroups []string      `json:"segmentGroups,omitempty"` // Parent group per segment, parallel to SegmentNames.
+	SegmentNotes  []string      `json:"segmentNotes,omitempty"`  // Markdown notes per segment, parallel to SegmentNames.
+	SegmentIcons  []string      `json:"segmentIcons,omitempty"`  // Icon asset ID per segment, parallel to SegmentNames.
+	CoverImage    string        `json:"coverImage,omitempty"`    // Cover image asset ID.
+	Variables     []Variable    `json:"variables,omitempty"`     // Sub-categories each attempt is tagged with.
+	Game          GameInfo      `json:"game,omitzero"`
+	Legs          []MarathonLeg `json:"legs,omitempty"` // Chained categories when the template is a marathon.
+	CreatedAt     time.Time     `json:"createdAt"`
+	UpdatedAt     time.Time     `json:"updatedAt"`
+}
+
+// GameInfo describes the game a template is for.
+type GameInfo struct {
+	Platform     string            `json:"platform,omitempty"`
+	Region       string            `json:"region,omitempty"`   // e.g. NTSC-U, PAL, JP.
+	Emulator     string            `json:"emulator,omitempty"` // Emulator or hardware the game is run on.
+	Abbreviation string            `json:"abbreviation,omitempty"`
+	ReleaseYear  int               `json:"releaseYear,omitempty"`
+	ExternalIDs  map[string]string `json:"externalIds,omitempty"` // IDs on other sites, keyed by site (e.g. "speedruncom").
 }
 
 // NewTemplate creates a new template with the given parameters.
@@ -23,3 +40,110 @@ func NewTemplate(id, name string, segmentNames []string) *Template {
 		UpdatedAt:    now,
 	}
 }
+
+// SetSegmentGroups assigns each segment to a named group (empty for ungrouped).
+// nil clears all groups. Returns false if the length differs from SegmentNames.
+func (t *Template) SetSegmentGroups(groups []string) bool {
+	if groups != nil && len(groups) != len(t.SegmentNames) {
+		return false
+	}
+
+	t.SegmentGroups = groups
+	t.UpdatedAt = time.Now()
+
+	return true
+}
+
+// SetSegmentNotes sets the markdown notes of the segment at index.
+func (t *Template) SetSegmentNotes(index int, notes string) bool {
+	if index < 0 || index >= len(t.SegmentNames) {
+		return false
+	}
+
+	t.SegmentNotes = padSegments(t.SegmentNotes, len(t.SegmentNames))
+	t.SegmentNotes[index] = notes
+	t.UpdatedAt = time.Now()
+
+	return true
+}
+
+// SetSegmentIcon sets the icon asset of the segment at index. An empty ID removes it.
+func (t *Template) SetSegmentIcon(index int, assetID string) bool {
+	if index < 0 || index >= len(t.SegmentNames) {
+		return false
+	}
+
+	t.SegmentIcons = padSegments(t.SegmentIcons, len(t.SegmentNames))
+	t.SegmentIcons[index] = assetID
+	t.UpdatedAt = time.Now()
+
+	return true
+}
+
+// AssetIDs returns every asset the template refers to.
+func (t *Template) AssetIDs() []string {
+	var ids []string
+
+	if t.CoverImage != "" {
+		ids = append(ids, t.CoverImage)
+	}
+
+	for _, id := range t.SegmentIcons {
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	return ids
+}
+
+// SetSegmentNames replaces the segment list. Groups, notes and icons follow
+// their segments by name; segments with new names start without any.
+func (t *Template) SetSegmentNames(names []string) {
+	sources := SuggestSegmentSources(t.SegmentNames, names)
+	groups := carryByIndex(t.SegmentGroups, sources)
+	notes := carryByIndex(t.SegmentNotes, sources)
+	icons := carryByIndex(t.SegmentIcons, sources)
+
+	t.SegmentNames = names
+	t.SegmentGroups = groups
+	t.SegmentNotes = notes
+	t.SegmentIcons = icons
+	t.UpdatedAt = time.Now()
+}
+
+// padSegments extends a per-segment slice to n entries.
+func padSegments(values []string, n int) []string {
+	if len(values) == n {
+		return values
+	}
+
+	padded := make([]string, n)
+	copy(padded, values)
+
+	return padded
+}
+
+// carryByIndex rebuilds a per-segment slice for a new segment list. Returns nil
+// if values was empty or nothing carried over.
+func carryByIndex(values []string, sources [][]int) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	out := make([]string, len(sources))
+	carried := false
+
+	for j, src := range sources {
+		if len(src) == 1 && src[0] < len(values) && values[src[0]] != "" {
+			out[j] = values[src[0]]
+			carried = true
+		}
+	}
+
+	if !carried {
+		return nil
+	}
+
+	return out
+}
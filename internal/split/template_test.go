@@ -25,3 +25,60 @@ func TestNewTemplate(t *testing.T) {
 		t.Fatal("expected non-zero CreatedAt")
 	}
 }
+
+func TestTemplateSetSegmentGroups(t *testing.T) {
+	tmpl := NewTemplate("t-1", "Game", []string{"1-1", "1-2", "2-1"})
+
+	if !tmpl.SetSegmentGroups([]string{"World 1", "World 1", "World 2"}) {
+		t.Fatal("expected groups to be set")
+	}
+
+	if tmpl.SegmentGroups[2] != "World 2" {
+		t.Fatalf("expected World 2, got %s", tmpl.SegmentGroups[2])
+	}
+
+	if tmpl.SetSegmentGroups([]string{"World 1"}) {
+		t.Fatal("expected mismatched group count to be rejected")
+	}
+}
+
+func TestTemplateSetSegmentNamesCarriesNotesAndGroups(t *testing.T) {
+	tmpl := NewTemplate("t-1", "Game", []string{"1-1", "1-2", "Boss"})
+	tmpl.SetSegmentGroups([]string{"World 1", "World 1", ""})
+	tmpl.SetSegmentNotes(2, "Dodge left")
+
+	tmpl.SetSegmentNames([]string{"1-1", "1-3", "1-2", "Boss"})
+
+	if len(tmpl.SegmentGroups) != 4 || tmpl.SegmentGroups[2] != "World 1" || tmpl.SegmentGroups[1] != "" {
+		t.Fatalf("unexpected groups: %v", tmpl.SegmentGroups)
+	}
+
+	if len(tmpl.SegmentNotes) != 4 || tmpl.SegmentNotes[3] != "Dodge left" {
+		t.Fatalf("unexpected notes: %v", tmpl.SegmentNotes)
+	}
+
+	if tmpl.SetSegmentNotes(4, "x") {
+		t.Fatal("expected out-of-range notes to be rejected")
+	}
+}
+
+func TestTemplateAssetIDs(t *testing.T) {
+	tmpl := NewTemplate("t-1", "Game", []string{"A", "B", "C"})
+	tmpl.CoverImage = "cover.png"
+
+	if !tmpl.SetSegmentIcon(2, "c.png") {
+		t.Fatal("expected icon to be set")
+	}
+
+	ids := tmpl.AssetIDs()
+	if len(ids) != 2 || ids[0] != "cover.png" || ids[1] != "c.png" {
+		t.Fatalf("unexpected asset IDs: %v", ids)
+	}
+
+	// Icons follow their segment when the list changes.
+	tmpl.SetSegmentNames([]string{"C", "A"})
+
+	if tmpl.SegmentIcons[0] != "c.png" {
+		t.Fatalf("unexpected icons after rename: %v", tmpl.SegmentIcons)
+	}
+}
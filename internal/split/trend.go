@@ -0,0 +1,111 @@
+package split
+
+const (
+	// trendMinSamples is the fewest segment times needed before a trend is flagged.
+	trendMinSamples = 5
+	// trendFlatRatio is the fitted change across the window, relative to the mean,
+	// below which a segment counts as not improving.
+	trendFlatRatio = 0.01
+)
+
+// SegmentTrend summarizes how a segment's times have moved across History.
+type SegmentTrend struct {
+	SegmentIndex      int     `json:"segmentIndex"`
+	Name              string  `json:"name"`
+	Samples           int     `json:"samples"`           // Segment times used for the fit.
+	MeanMS            int64   `json:"meanMs"`            // Mean segment time within the window.
+	SlopeMS           float64 `json:"slopeMs"`           // Fitted change per attempt. Positive = slower.
+	ImprovementRate   float64 `json:"improvementRate"`   // Percent of the mean gained per attempt. Negative = losing time.
+	AttemptsSinceGold int     `json:"attemptsSinceGold"` // Samples recorded since the segment's best time.
+	Regressing        bool    `json:"regressing"`        // True if the segment is getting slower.
+	Stalled           bool    `json:"stalled"`           // True if flat with no gold in the recent half of the window.
+}
+
+// SegmentTrends fits a least-squares line through each segment's times across
+// History, oldest first. Only the most recent window samples of each segment are
+// fitted (window <= 0 uses all of them). Skipped segments contribute no sample.
+func (a *Attempts) SegmentTrends(window int) []SegmentTrend {
+	samples := make([][]int64, len(a.Segments))
+
+	for _, att := range a.History {
+		for i := range att.SplitTimesMS {
+			if i >= len(a.Segments) {
+				break
+			}
+
+			if segTime, ok := att.SegmentTimeMS(i); ok {
+				samples[i] = append(samples[i], segTime)
+			}
+		}
+	}
+
+	trends := make([]SegmentTrend, len(a.Segments))
+
+	for i, seg := range a.Segments {
+		trends[i] = segmentTrend(i, seg.Name, samples[i], window)
+	}
+
+	return trends
+}
+
+func segmentTrend(index int, name string, times []int64, window int) SegmentTrend {
+	tr := SegmentTrend{SegmentIndex: index, Name: name}
+	if len(times) == 0 {
+		return tr
+	}
+
+	bestIdx := 0
+	for i, t := range times {
+		if t < times[bestIdx] {
+			bestIdx = i
+		}
+	}
+
+	tr.AttemptsSinceGold = len(times) - 1 - bestIdx
+
+	if window > 0 && len(times) > window {
+		times = times[len(times)-window:]
+	}
+
+	tr.Samples = len(times)
+
+	n := float64(len(times))
+	var sumX, sumY float64
+
+	for i, t := range times {
+		sumX += float64(i)
+		sumY += float64(t)
+	}
+
+	meanX := sumX / n
+	meanY := sumY / n
+	tr.MeanMS = int64(meanY)
+
+	var num, den float64
+
+	for i, t := range times {
+		dx := float64(i) - meanX
+		num += dx * (float64(t) - meanY)
+		den += dx * dx
+	}
+
+	if den > 0 {
+		tr.SlopeMS = num / den
+	}
+
+	if meanY > 0 {
+		tr.ImprovementRate = -tr.SlopeMS / meanY * 100
+	}
+
+	if len(times) < trendMinSamples || meanY <= 0 {
+		return tr
+	}
+
+	// Compare the fitted change across the whole window to the mean so slow and
+	// fast segments are judged on the same scale.
+	change := tr.SlopeMS * (n - 1) / meanY
+	tr.Regressing = change > trendFlatRatio
+	tr.Stalled = !tr.Regressing && change > -trendFlatRatio && tr.AttemptsSinceGold >= len(times)/2
+
+	return tr
+}
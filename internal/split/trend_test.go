@@ -0,0 +1,105 @@
+package split
+
+import "testing"
+
+func TestSegmentTrendsImproving(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+
+	// Segment A improves by 100ms per attempt, segment B holds steady at 2000ms.
+	for i := int64(0); i < 6; i++ {
+		a := 1500 - i*100
+		att.AddAttempt([]int64{a, a + 2000}, true)
+	}
+
+	trends := att.SegmentTrends(0)
+	if len(trends) != 2 {
+		t.Fatalf("expected 2 trends, got %d", len(trends))
+	}
+
+	if trends[0].Samples != 6 {
+		t.Fatalf("expected 6 samples, got %d", trends[0].Samples)
+	}
+
+	if trends[0].SlopeMS != -100 {
+		t.Fatalf("expected slope -100, got %f", trends[0].SlopeMS)
+	}
+
+	if trends[0].ImprovementRate <= 0 {
+		t.Fatalf("expected positive improvement rate, got %f", trends[0].ImprovementRate)
+	}
+
+	if trends[0].Regressing || trends[0].Stalled {
+		t.Fatalf("expected improving segment to be unflagged, got %+v", trends[0])
+	}
+
+	if trends[1].SlopeMS != 0 {
+		t.Fatalf("expected flat slope for B, got %f", trends[1].SlopeMS)
+	}
+
+	if !trends[1].Stalled {
+		t.Fatalf("expected B to be stalled, got %+v", trends[1])
+	}
+}
+
+func TestSegmentTrendsRegressing(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A"})
+
+	for i := int64(0); i < 5; i++ {
+		att.AddAttempt([]int64{1000 + i*50}, true)
+	}
+
+	tr := att.SegmentTrends(0)[0]
+	if !tr.Regressing {
+		t.Fatalf("expected regressing, got %+v", tr)
+	}
+
+	if tr.AttemptsSinceGold != 4 {
+		t.Fatalf("expected 4 attempts since gold, got %d", tr.AttemptsSinceGold)
+	}
+}
+
+func TestSegmentTrendsWindow(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A"})
+
+	// Early improvement followed by a slowdown in the most recent attempts.
+	for _, v := range []int64{3000, 2500, 2000, 1500, 1000, 1100, 1200, 1300, 1400} {
+		att.AddAttempt([]int64{v}, false)
+	}
+
+	if att.SegmentTrends(0)[0].Regressing {
+		t.Fatal("expected full history to read as improving")
+	}
+
+	tr := att.SegmentTrends(5)[0]
+	if tr.Samples != 5 {
+		t.Fatalf("expected 5 samples in window, got %d", tr.Samples)
+	}
+
+	if !tr.Regressing {
+		t.Fatalf("expected recent window to be regressing, got %+v", tr)
+	}
+}
+
+func TestSegmentTrendsSkipsMissingTimes(t *testing.T) {
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B", "C"})
+	att.AddAttempt([]int64{1000, 0, 3000}, true)
+	att.AddAttempt([]int64{1000}, false)
+
+	trends := att.SegmentTrends(0)
+
+	if trends[0].Samples != 2 {
+		t.Fatalf("expected 2 samples for A, got %d", trends[0].Samples)
+	}
+
+	if trends[1].Samples != 0 {
+		t.Fatalf("expected no samples for skipped B, got %d", trends[1].Samples)
+	}
+
+	if trends[2].Samples != 1 || trends[2].MeanMS != 2000 {
+		t.Fatalf("expected one 2000ms sample for C, got %+v", trends[2])
+	}
+
+	if trends[2].Regressing || trends[2].Stalled {
+		t.Fatalf("expected too few samples to flag C, got %+v", trends[2])
+	}
+}
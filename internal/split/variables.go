@@ -0,0 +1,135 @@
+package split
+
+import (
+	"maps"
+	"slices"
+	"time"
+)
+
+// Variable is a template-level sub-category such as Platform or Version. Each
+// attempt is tagged with one of its values.
+type Variable struct {
+	Name    string   `json:"name"`
+	Values  []string `json:"values"`
+	Default string   `json:"default,omitempty"` // Value assumed for attempts recorded without one.
+}
+
+// SetVariables replaces the template's variables. Returns false if a name is empty
+// or repeated, a variable has no values, or a default is not one of its values.
+func (t *Template) SetVariables(vars []Variable) bool {
+	seen := make(map[string]bool)
+
+	for _, v := range vars {
+		if v.Name == "" || seen[v.Name] || len(v.Values) == 0 {
+			return false
+		}
+
+		if v.Default != "" && !slices.Contains(v.Values, v.Default) {
+			return false
+		}
+
+		seen[v.Name] = true
+	}
+
+	t.Variables = vars
+	t.UpdatedAt = time.Now()
+
+	return true
+}
+
+// VariableValue returns the attempt's value for v, or v's default if it has none.
+func (at Attempt) VariableValue(v Variable) string {
+	if value, ok := at.Variables[v.Name]; ok {
+		return value
+	}
+
+	return v.Default
+}
+
+// MatchesVariables reports whether the attempt has every value in values.
+// An empty value matches anything.
+func (at Attempt) MatchesVariables(vars []Variable, values map[string]string) bool {
+	for name, want := range values {
+		if want == "" {
+			continue
+		}
+
+		v := Variable{Name: name}
+		if i := slices.IndexFunc(vars, func(v Variable) bool { return v.Name == name }); i >= 0 {
+			v = vars[i]
+		}
+
+		if at.VariableValue(v) != want {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SetVariableValues sets the values tagged onto attempts recorded from now on.
+// An empty value leaves the variable untagged, so its default applies. Returns
+// false if a name is not one of vars or a value is not one of its values.
+func (a *Attempts) SetVariableValues(vars []Variable, values map[string]string) bool {
+	tags, ok := variableTags(vars, values)
+	if !ok {
+		return false
+	}
+
+	a.VariableValues = tags
+	a.UpdatedAt = time.Now()
+
+	return true
+}
+
+// SetAttemptVariables replaces the variable values of a recorded attempt, with
+// the same rules as SetVariableValues. Returns false if the attempt does not
+// exist or the values do not fit vars.
+func (a *Attempts) SetAttemptVariables(vars []Variable, attemptID int, values map[string]string) bool {
+	at := a.findAttempt(attemptID)
+	if at == nil {
+		return false
+	}
+
+	tags, ok := variableTags(vars, values)
+	if !ok {
+		return false
+	}
+
+	at.Variables = tags
+	a.UpdatedAt = time.Now()
+
+	return true
+}
+
+// variableTags checks values against vars and returns them without the empty
+// ones, or false if a name or value is unknown.
+func variableTags(vars []Variable, values map[string]string) (map[string]string, bool) {
+	for name, value := range values {
+		i := slices.IndexFunc(vars, func(v Variable) bool { return v.Name == name })
+		if i < 0 || (value != "" && !slices.Contains(vars[i].Values, value)) {
+			return nil, false
+		}
+	}
+
+	tags := maps.Clone(values)
+	maps.DeleteFunc(tags, func(_, value string) bool { return value == "" })
+
+	return tags, true
+}
+
+// ForVariables returns a read-only view holding only the attempts that match
+// values, so PB, golds and comparisons can be computed for one combination of
+// variables. vars supplies the defaults for untagged attempts.
+func (a *Attempts) ForVariables(vars []Variable, values map[string]string) *Attempts {
+	view := *a
+	view.History = nil
+
+	for _, at := range a.History {
+		if at.MatchesVariables(vars, values) {
+			view.History = append(view.History, at)
+		}
+	}
+
+	return &view
+}
@@ -0,0 +1,103 @@
+package split
+
+import "testing"
+
+func TestTemplateSetVariables(t *testing.T) {
+	tmpl := NewTemplate("t-1", "Game", []string{"A"})
+
+	if !tmpl.SetVariables([]Variable{{Name: "Platform", Values: []string{"PC", "Console"}, Default: "PC"}}) {
+		t.Fatal("expected variables to be set")
+	}
+
+	invalid := [][]Variable{
+		{{Name: "", Values: []string{"x"}}},
+		{{Name: "V", Values: nil}},
+		{{Name: "V", Values: []string{"1.0"}, Default: "1.1"}},
+		{{Name: "V", Values: []string{"1.0"}}, {Name: "V", Values: []string{"1.1"}}},
+	}
+
+	for i, vars := range invalid {
+		if tmpl.SetVariables(vars) {
+			t.Fatalf("case %d: expected variables to be rejected", i)
+		}
+	}
+
+	if len(tmpl.Variables) != 1 {
+		t.Fatalf("expected rejected variables to leave the template unchanged, got %v", tmpl.Variables)
+	}
+}
+
+func TestRecordAttemptTagsVariables(t *testing.T) {
+	vars := []Variable{{Name: "Platform", Values: []string{"PC", "Console"}, Default: "PC"}}
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+	att.SetVariableValues(vars, map[string]string{"Platform": "Console"})
+	att.AddAttempt([]int64{1000, 2000}, true)
+
+	if got := att.History[0].Variables["Platform"]; got != "Console" {
+		t.Fatalf("expected attempt tagged Console, got %q", got)
+	}
+
+	if !att.SetAttemptVariables(vars, 1, map[string]string{"Platform": "PC"}) {
+		t.Fatal("expected retag to succeed")
+	}
+
+	if att.SetAttemptVariables(vars, 99, nil) {
+		t.Fatal("expected unknown attempt to fail")
+	}
+}
+
+func TestVariableValuesMustFitTemplate(t *testing.T) {
+	vars := []Variable{{Name: "Platform", Values: []string{"PC", "Console"}, Default: "PC"}}
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A"})
+	att.AddAttempt([]int64{1000}, true)
+
+	invalid := []map[string]string{
+		{"Platfrom": "PC"},
+		{"Platform": "Switch"},
+	}
+
+	for i, values := range invalid {
+		if att.SetVariableValues(vars, values) || att.SetAttemptVariables(vars, 1, values) {
+			t.Fatalf("case %d: expected values to be rejected", i)
+		}
+	}
+
+	if att.VariableValues != nil || att.History[0].Variables != nil {
+		t.Fatal("expected rejected values to leave the entry unchanged")
+	}
+
+	// An empty value clears the tag, so the default applies.
+	if !att.SetAttemptVariables(vars, 1, map[string]string{"Platform": ""}) || att.History[0].VariableValue(vars[0]) != "PC" {
+		t.Fatalf("expected an empty value to fall back to the default, got %v", att.History[0].Variables)
+	}
+}
+
+func TestForVariables(t *testing.T) {
+	vars := []Variable{{Name: "Platform", Values: []string{"PC", "Console"}, Default: "PC"}}
+	att := NewAttempts("a-1", "t-1", "", "Any%", []string{"A", "B"})
+
+	// Recorded before variables existed, so it counts as the default (PC).
+	att.AddAttempt([]int64{1000, 3000}, true)
+
+	att.SetVariableValues(vars, map[string]string{"Platform": "Console"})
+	att.AddAttempt([]int64{800, 2500}, true)
+
+	pc := att.ForVariables(vars, map[string]string{"Platform": "PC"})
+	if pb := pc.PersonalBestSplits(); pb == nil || pb[1] != 3000 {
+		t.Fatalf("expected PC PB of 3000, got %v", pb)
+	}
+
+	console := att.ForVariables(vars, map[string]string{"Platform": "Console"})
+	if best := console.BestSegments(); best[0] != 800 || best[1] != 1700 {
+		t.Fatalf("unexpected Console golds: %v", best)
+	}
+
+	all := att.ForVariables(vars, map[string]string{"Platform": ""})
+	if len(all.History) != 2 {
+		t.Fatalf("expected empty value to match everything, got %d attempts", len(all.History))
+	}
+
+	if len(att.History) != 2 {
+		t.Fatal("expected the view to leave the entry unchanged")
+	}
+}
@@ -0,0 +1,161 @@
+package timer
+
+import (
+	"sync"
+	"time"
+)
+
+// CountdownState is emitted whenever the countdown's whole-second count changes,
+// when it reaches zero, and when it is cancelled.
+type CountdownState struct {
+	Active      bool  `json:"active"`
+	Count       int   `json:"count"` // Whole seconds left, rounded up; 0 at go.
+	RemainingMS int64 `json:"remainingMs"`
+	GoAtMS      int64 `json:"goAtMs"` // Unix milliseconds of the go moment.
+	Cancelled   bool  `json:"cancelled"`
+}
+
+// OnCountdownFunc is called with each countdown state change.
+type OnCountdownFunc func(CountdownState)
+
+// OnGoFunc is called once the countdown reaches zero, with the exact go time.
+type OnGoFunc func(time.Time)
+
+// Countdown counts down to an absolute go time, so timers on several machines
+// with synced clocks start together.
+type Countdown struct {
+	mu sync.Mutex
+
+	goAt time.Time
+	stop chan struct{} // nil while inactive
+
+	onState OnCountdownFunc
+	onGo    OnGoFunc
+}
+
+// NewCountdown creates an inactive countdown.
+func NewCountdown(onState OnCountdownFunc, onGo OnGoFunc) *Countdown {
+	return &Countdown{onState: onState, onGo: onGo}
+}
+
+// Start counts down to goAt. Returns false if a countdown is already active or
+// goAt is not in the future.
+func (c *Countdown) Start(goAt time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stop != nil || !goAt.After(time.Now()) {
+		return false
+	}
+
+	c.goAt = goAt
+	c.stop = make(chan struct{})
+
+	go c.run(goAt, c.stop)
+
+	return true
+}
+
+// Cancel stops an active countdown without going. Returns false if none was active.
+func (c *Countdown) Cancel() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stop == nil {
+		return false
+	}
+
+	close(c.stop)
+	c.stop = nil
+	c.notify(CountdownState{GoAtMS: c.goAt.UnixMilli(), Cancelled: true})
+
+	return true
+}
+
+// Active reports whether a countdown is running.
+func (c *Countdown) Active() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stop != nil
+}
+
+// State returns the current countdown state.
+func (c *Countdown) State() CountdownState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.stop == nil {
+		return CountdownState{}
+	}
+
+	return stateAt(c.goAt, time.Until(c.goAt))
+}
+
+// run wakes at each whole-second boundary before goAt, then at goAt itself.
+func (c *Countdown) run(goAt time.Time, stop chan struct{}) {
+	for {
+		remaining := time.Until(goAt)
+		if remaining <= 0 {
+			c.fire(goAt, stop)
+
+			return
+		}
+
+		c.mu.Lock()
+		if c.stop == stop {
+			c.notify(stateAt(goAt, remaining))
+		}
+		c.mu.Unlock()
+
+		wait := remaining % time.Second
+		if wait == 0 {
+			wait = time.Second
+		}
+
+		t := time.NewTimer(wait)
+
+		select {
+		case <-t.C:
+		case <-stop:
+			t.Stop()
+
+			return
+		}
+	}
+}
+
+// fire ends the countdown and starts the run, unless it was cancelled meanwhile.
+func (c *Countdown) fire(goAt time.Time, stop chan struct{}) {
+	c.mu.Lock()
+
+	if c.stop != stop {
+		c.mu.Unlock()
+
+		return
+	}
+
+	c.stop = nil
+	c.notify(CountdownState{GoAtMS: goAt.UnixMilli()})
+	c.mu.Unlock()
+
+	// Called unlocked so the handler may start another countdown.
+	if c.onGo != nil {
+		c.onGo(goAt)
+	}
+}
+
+func (c *Countdown) notify(state CountdownState) {
+	if c.onState != nil {
+		c.onState(state)
+	}
+}
+
+func stateAt(goAt time.Time, remaining time.Duration) CountdownState {
+	return CountdownState{
+		Active:      true,
+		Count:       int((remaining + time.Second - 1) / time.Second),
+		RemainingMS: remaining.Milliseconds(),
+		GoAtMS:      goAt.UnixMilli(),
+	}
+}
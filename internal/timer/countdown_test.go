@@ -0,0 +1,98 @@
+package timer
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCountdownGoes(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		states []CountdownState
+	)
+
+	went := make(chan time.Time, 1)
+	c := NewCountdown(func(s CountdownState) {
+		mu.Lock()
+		states = append(states, s)
+		mu.Unlock()
+	}, func(at time.Time) { went <- at })
+
+	goAt := time.Now().Add(50 * time.Millisecond)
+	if !c.Start(goAt) {
+		t.Fatal("expected countdown to start")
+	}
+
+	if c.Start(goAt.Add(time.Second)) {
+		t.Fatal("expected second start to be rejected while active")
+	}
+
+	select {
+	case at := <-went:
+		if !at.Equal(goAt) {
+			t.Fatalf("expected go at %v, got %v", goAt, at)
+		}
+
+		if late := time.Since(goAt); late < 0 {
+			t.Fatalf("went %v early", -late)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("countdown never went")
+	}
+
+	if c.Active() {
+		t.Fatal("expected countdown to be inactive after go")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if len(states) < 2 || states[0].Count != 1 || !states[0].Active {
+		t.Fatalf("unexpected states: %+v", states)
+	}
+
+	if last := states[len(states)-1]; last.Active || last.Count != 0 || last.Cancelled {
+		t.Fatalf("expected final go state, got %+v", last)
+	}
+}
+
+func TestCountdownCancel(t *testing.T) {
+	went := make(chan time.Time, 1)
+	c := NewCountdown(nil, func(at time.Time) { went <- at })
+
+	if c.Cancel() {
+		t.Fatal("expected cancel without countdown to fail")
+	}
+
+	c.Start(time.Now().Add(30 * time.Millisecond))
+
+	if !c.Cancel() {
+		t.Fatal("expected cancel to succeed")
+	}
+
+	select {
+	case <-went:
+		t.Fatal("cancelled countdown went")
+	case <-time.After(80 * time.Millisecond):
+	}
+}
+
+func TestCountdownRejectsPast(t *testing.T) {
+	c := NewCountdown(nil, nil)
+
+	if c.Start(time.Now().Add(-time.Second)) {
+		t.Fatal("expected a go time in the past to be rejected")
+	}
+}
+
+func TestStartSince(t *testing.T) {
+	e := New(segments(), nil, nil)
+	e.StartSince(time.Now().Add(-500 * time.Millisecond))
+
+	if ms := e.ElapsedMS(); ms < 500 {
+		t.Fatalf("expected elapsed from the given start, got %d", ms)
+	}
+
+	e.Reset()
+}
@@ -13,6 +13,7 @@ type TickData struct {
 	SplitTimesMS   []int64  `json:"splitTimesMs"`
 	SegmentTimesMS []int64  `json:"segmentTimesMs"`
 	SplitNames     []string `json:"splitNames"`
+	Hits           []int    `json:"hits"` // Hits taken per segment so far.
 }
 
 // OnTickFunc is called on every timer tick with current data.
@@ -21,6 +22,9 @@ type OnTickFunc func(TickData)
 // OnStateChangeFunc is called when the timer state changes.
 type OnStateChangeFunc func(State)
 
+// OnSegmentChangeFunc is called with the new index when the current segment changes.
+type OnSegmentChangeFunc func(int)
+
 // Engine is a high-precision speedrun timer.
 type Engine struct {
 	mu sync.RWMutex
@@ -34,12 +38,15 @@ type Engine struct {
 	splitTimesMS   []int64 // cumulative split times in ms
 	segmentTimesMS []int64 // individual segment durations in ms
 	currentSegment int
+	startSegment   int   // first segment actually run; earlier splits were preloaded
+	hits           []int // hits per segment, for no-hit runs
 
 	ticker   *time.Ticker
 	stopChan chan struct{}
 
-	onTick        OnTickFunc
-	onStateChange OnStateChangeFunc
+	onTick          OnTickFunc
+	onStateChange   OnStateChangeFunc
+	onSegmentChange OnSegmentChangeFunc
 }
 
 // New creates a new timer engine with the given segment names.
@@ -51,6 +58,15 @@ func New(segmentNames []string, onTick OnTickFunc, onStateChange OnStateChangeFu
 	}
 }
 
+// SetSegmentChangeHandler registers a callback for current segment changes.
+// Like the other callbacks, it runs with the engine locked and must not call back into it.
+func (e *Engine) SetSegmentChangeHandler(fn OnSegmentChangeFunc) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.onSegmentChange = fn
+}
+
 // SetSegments replaces the segment list (only valid in Idle state).
 func (e *Engine) SetSegments(names []string) {
 	e.mu.Lock()
@@ -98,8 +114,33 @@ func (e *Engine) elapsedMS() int64 {
 	return 0
 }
 
+// PausedMS returns the total time spent paused in the current run, including
+// an ongoing pause.
+func (e *Engine) PausedMS() int64 {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	switch e.state {
+	case Idle:
+		return 0
+	case Paused:
+		return (e.pauseAccum + time.Since(e.pauseTime)).Milliseconds()
+	case Running, Finished:
+		return e.pauseAccum.Milliseconds()
+	}
+
+	return 0
+}
+
 // Start begins the timer. Only valid from Idle state.
 func (e *Engine) Start() {
+	e.StartSince(time.Now())
+}
+
+// StartSince begins the timer as if it had started at the given time, so a
+// countdown's go moment is honored exactly however late the call comes.
+// Times in the future start now. Only valid from Idle state.
+func (e *Engine) StartSince(at time.Time) {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
@@ -107,15 +148,62 @@ func (e *Engine) Start() {
 		return
 	}
 
+	if now := time.Now(); at.After(now) {
+		at = now
+	}
+
 	e.state = Running
-	e.startTime = time.Now()
+	e.startTime = at
 	e.pauseAccum = 0
 	e.currentSegment = 0
+	e.startSegment = 0
 	e.splitTimesMS = nil
 	e.segmentTimesMS = nil
+	e.hits = make([]int, len(e.segmentNames))
 
 	e.startTicker()
 	e.notifyStateChange()
+	e.notifySegmentChange()
+}
+
+// StartAt begins the timer at segment with the splits before it preloaded, as
+// if those segments had been run. The elapsed time starts at the last non-zero
+// preloaded split. UndoSplit never reverts a preloaded split. Only valid from
+// Idle state, with one split per earlier segment.
+func (e *Engine) StartAt(segment int, splitTimesMS []int64) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.state != Idle || segment < 0 || segment >= len(e.segmentNames) || len(splitTimesMS) != segment {
+		return
+	}
+
+	var elapsed, prev int64
+
+	e.splitTimesMS = make([]int64, segment)
+	e.segmentTimesMS = make([]int64, segment)
+
+	for i, ms := range splitTimesMS {
+		e.splitTimesMS[i] = ms
+		if ms == 0 {
+			continue
+		}
+
+		e.segmentTimesMS[i] = ms - prev
+		prev = ms
+		elapsed = ms
+	}
+
+	e.state = Running
+	e.startTime = time.Now().Add(-time.Duration(elapsed) * time.Millisecond)
+	e.pauseAccum = 0
+	e.currentSegment = segment
+	e.startSegment = segment
+	e.hits = make([]int, len(e.segmentNames))
+
+	e.startTicker()
+	e.notifyStateChange()
+	e.notifySegmentChange()
 }
 
 // Split records the current segment time. Only valid from Running state.
@@ -146,6 +234,8 @@ func (e *Engine) Split() {
 		e.notifyTick()
 		e.notifyStateChange()
 	}
+
+	e.notifySegmentChange()
 }
 
 // SkipSplit skips the current segment without recording a time.
@@ -167,20 +257,54 @@ func (e *Engine) SkipSplit() {
 		e.notifyTick()
 		e.notifyStateChange()
 	}
+
+	e.notifySegmentChange()
 }
 
-// UndoSplit reverts the last split. Only valid from Running state.
+// UndoSplit reverts the last split. Only valid from Running state, and never
+// past the segment the run started on.
 func (e *Engine) UndoSplit() {
 	e.mu.Lock()
 	defer e.mu.Unlock()
 
-	if e.state != Running || e.currentSegment == 0 {
+	if e.state != Running || e.currentSegment <= e.startSegment {
 		return
 	}
 
 	e.splitTimesMS = e.splitTimesMS[:len(e.splitTimesMS)-1]
 	e.segmentTimesMS = e.segmentTimesMS[:len(e.segmentTimesMS)-1]
 	e.currentSegment--
+
+	// Hits taken since the undone split belong to the segment being resumed.
+	e.hits[e.currentSegment] += e.hits[e.currentSegment+1]
+	e.hits[e.currentSegment+1] = 0
+	e.notifyTick()
+	e.notifySegmentChange()
+}
+
+// Hit counts a hit in the current segment. Only valid from Running state.
+func (e *Engine) Hit() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.state != Running || e.currentSegment >= len(e.hits) {
+		return
+	}
+
+	e.hits[e.currentSegment]++
+	e.notifyTick()
+}
+
+// UndoHit removes a hit from the current segment. Only valid from Running state.
+func (e *Engine) UndoHit() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.state != Running || e.currentSegment >= len(e.hits) || e.hits[e.currentSegment] == 0 {
+		return
+	}
+
+	e.hits[e.currentSegment]--
 	e.notifyTick()
 }
 
@@ -230,13 +354,16 @@ func (e *Engine) Restore(elapsedMS int64, currentSegment int, splitTimesMS, segm
 	e.pauseTime = now
 	e.pauseAccum = 0
 	e.currentSegment = currentSegment
+	e.startSegment = 0
 	e.splitTimesMS = make([]int64, len(splitTimesMS))
 	copy(e.splitTimesMS, splitTimesMS)
 	e.segmentTimesMS = make([]int64, len(segmentTimesMS))
 	copy(e.segmentTimesMS, segmentTimesMS)
+	e.hits = make([]int, len(e.segmentNames))
 
 	e.notifyTick()
 	e.notifyStateChange()
+	e.notifySegmentChange()
 }
 
 // Reset stops the timer and returns to Idle. Valid from any state except Idle.
@@ -251,10 +378,13 @@ func (e *Engine) Reset() {
 	e.stopTicker()
 	e.state = Idle
 	e.currentSegment = 0
+	e.startSegment = 0
 	e.splitTimesMS = nil
 	e.segmentTimesMS = nil
+	e.hits = nil
 	e.notifyTick()
 	e.notifyStateChange()
+	e.notifySegmentChange()
 }
 
 // GetTickData returns the current tick data snapshot.
@@ -275,6 +405,9 @@ func (e *Engine) tickData() TickData {
 	namesCopy := make([]string, len(e.segmentNames))
 	copy(namesCopy, e.segmentNames)
 
+	hitsCopy := make([]int, len(e.hits))
+	copy(hitsCopy, e.hits)
+
 	return TickData{
 		ElapsedMS:      e.elapsedMS(),
 		State:          e.state.String(),
@@ -282,6 +415,7 @@ func (e *Engine) tickData() TickData {
 		SplitTimesMS:   splitsCopy,
 		SegmentTimesMS: segsCopy,
 		SplitNames:     namesCopy,
+		Hits:           hitsCopy,
 	}
 }
 
@@ -330,6 +464,12 @@ func (e *Engine) notifyStateChange() {
 	}
 }
 
+func (e *Engine) notifySegmentChange() {
+	if e.onSegmentChange != nil {
+		e.onSegmentChange(e.currentSegment)
+	}
+}
+
 // SplitTimesMS returns a copy of the recorded split times.
 func (e *Engine) SplitTimesMS() []int64 {
 	e.mu.RLock()
@@ -359,3 +499,41 @@ func (e *Engine) CurrentSegment() int {
 
 	return e.currentSegment
 }
+
+// Hits returns a copy of the hits taken per segment in the current run.
+func (e *Engine) Hits() []int {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	result := make([]int, len(e.hits))
+	copy(result, e.hits)
+
+	return result
+}
+
+// RestoreHits sets the per-segment hits of a restored run. Only valid from
+// Paused state, right after Restore.
+func (e *Engine) RestoreHits(hits []int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.state != Paused {
+		return
+	}
+
+	copy(e.hits, hits)
+}
+
+// RestoreStartSegment sets the segment a restored partial-start run began on,
+// so UndoSplit cannot revert its preloaded splits. Only valid from Paused
+// state, right after Restore.
+func (e *Engine) RestoreStartSegment(segment int) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.state != Paused || segment < 0 || segment > e.currentSegment {
+		return
+	}
+
+	e.startSegment = segment
+}
@@ -76,6 +76,37 @@ func TestPauseResume(t *testing.T) {
 	e.Reset()
 }
 
+func TestPausedMS(t *testing.T) {
+	e := New(segments(), nil, nil)
+
+	if e.PausedMS() != 0 {
+		t.Fatalf("expected 0 paused ms when idle, got %d", e.PausedMS())
+	}
+
+	e.Start()
+	e.Pause()
+	time.Sleep(50 * time.Millisecond)
+
+	// An ongoing pause counts.
+	if e.PausedMS() < 50 {
+		t.Fatalf("expected at least 50ms paused, got %d", e.PausedMS())
+	}
+
+	e.Resume()
+	paused := e.PausedMS()
+	time.Sleep(20 * time.Millisecond)
+
+	if e.PausedMS() != paused {
+		t.Fatalf("paused ms changed while running: %d -> %d", paused, e.PausedMS())
+	}
+
+	e.Reset()
+
+	if e.PausedMS() != 0 {
+		t.Fatalf("expected 0 paused ms after reset, got %d", e.PausedMS())
+	}
+}
+
 func TestSplitCompleteRun(t *testing.T) {
 	e := New(segments(), nil, nil)
 	e.Start()
@@ -361,3 +392,119 @@ func TestSetSegmentsWhileRunning(t *testing.T) {
 
 	e.Reset()
 }
+
+func TestSegmentChangeCallback(t *testing.T) {
+	var indices []int
+
+	e := New(segments(), nil, nil)
+	e.SetSegmentChangeHandler(func(i int) {
+		indices = append(indices, i)
+	})
+
+	e.Start()
+	e.Split()
+	e.SkipSplit()
+	e.UndoSplit()
+	e.Reset()
+
+	expected := []int{0, 1, 2, 1, 0}
+	if len(indices) != len(expected) {
+		t.Fatalf("expected %d segment changes, got %d: %v", len(expected), len(indices), indices)
+	}
+
+	for i, idx := range indices {
+		if idx != expected[i] {
+			t.Fatalf("change[%d]: expected %d, got %d", i, expected[i], idx)
+		}
+	}
+}
+
+func TestStartAt(t *testing.T) {
+	e := New(segments(), nil, nil)
+
+	e.StartAt(2, []int64{1000, 3000})
+
+	if e.CurrentState() != Running || e.CurrentSegment() != 2 {
+		t.Fatalf("expected running on segment 2, got %v on %d", e.CurrentState(), e.CurrentSegment())
+	}
+
+	if elapsed := e.ElapsedMS(); elapsed < 3000 || elapsed > 3100 {
+		t.Fatalf("expected elapsed preloaded to ~3000, got %d", elapsed)
+	}
+
+	e.Split()
+
+	splits := e.SplitTimesMS()
+	if e.CurrentState() != Finished || len(splits) != 3 || splits[1] != 3000 || splits[2] < 3000 {
+		t.Fatalf("unexpected splits after finishing: %v", splits)
+	}
+}
+
+func TestStartAtKeepsPreloadedSplits(t *testing.T) {
+	e := New(segments(), nil, nil)
+
+	e.StartAt(2, []int64{1000, 3000})
+	e.UndoSplit()
+
+	if e.CurrentSegment() != 2 || len(e.SplitTimesMS()) != 2 {
+		t.Fatalf("expected undo not to revert a preloaded split, got segment %d", e.CurrentSegment())
+	}
+
+	e.Reset()
+	e.Restore(5000, 2, []int64{1000, 3000}, []int64{1000, 2000})
+	e.RestoreStartSegment(2)
+	e.Resume()
+	e.UndoSplit()
+
+	if e.CurrentSegment() != 2 {
+		t.Fatalf("expected undo not to revert a restored preloaded split, got segment %d", e.CurrentSegment())
+	}
+}
+
+func TestStartAtRejectsInvalid(t *testing.T) {
+	e := New(segments(), nil, nil)
+
+	e.StartAt(2, []int64{1000})
+	e.StartAt(3, []int64{1000, 2000, 3000})
+
+	if e.CurrentState() != Idle {
+		t.Fatalf("expected invalid starts to be ignored, got %v", e.CurrentState())
+	}
+}
+
+func TestHits(t *testing.T) {
+	e := New(segments(), nil, nil)
+
+	e.Hit() // Ignored while idle.
+	e.Start()
+	e.Hit()
+	e.Hit()
+	e.UndoHit()
+	e.Split()
+	e.Hit()
+	e.Hit()
+
+	if h := e.Hits(); h[0] != 1 || h[1] != 2 || h[2] != 0 {
+		t.Fatalf("unexpected hits: %v", h)
+	}
+
+	// Undoing the split folds the later hits back into the first segment.
+	e.UndoSplit()
+
+	if h := e.Hits(); h[0] != 3 || h[1] != 0 {
+		t.Fatalf("expected hits to fold back on undo, got %v", h)
+	}
+
+	e.Pause()
+	e.Hit()
+
+	if h := e.Hits(); h[0] != 3 {
+		t.Fatalf("hit counted while paused: %v", h)
+	}
+
+	e.Reset()
+
+	if len(e.Hits()) != 0 {
+		t.Fatalf("expected no hits after reset, got %v", e.Hits())
+	}
+}
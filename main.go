@@ -2,6 +2,7 @@ package main
 
 import (
 	"embed"
+	"net/http"
 	"strings"
 
 	"github.com/wailsapp/wails/v2"
@@ -30,7 +31,8 @@ func main() {
 		MinWidth:  400,
 		MinHeight: 500,
 		AssetServer: &assetserver.Options{
-			Assets: assets,
+			Assets:  assets,
+			Handler: http.HandlerFunc(app.serveAsset),
 		},
 		BackgroundColour: &options.RGBA{R: 18, G: 18, B: 24, A: 255},
 		Mac: &mac.Options{